@@ -0,0 +1,95 @@
+package jsonx
+
+import (
+	"errors"
+	"github.com/aivyss/jsonx/bind"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// File
+// an uploaded file bound to a field tagged `form:"avatar"`, see @MaxBytes and @ContentType
+type File = bind.File
+
+// Sources
+// request values bound to fields tagged `query:"page"`, `header:"X-Tenant"`, `path:"id"` and `form:"name"`
+type Sources struct {
+	Query  url.Values
+	Header http.Header
+	Path   map[string]string
+	Form   url.Values
+	Files  map[string][]*multipart.FileHeader
+}
+
+// BindValues
+// binds sources into a new T and validates it like Unmarshal
+func BindValues[T any](sources Sources) (*T, error) {
+	v := new(T)
+	if err := DecodeValues(v, sources); err != nil {
+		return nil, err
+	}
+
+	if err := Validate(*v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func BindQuery[T any](query url.Values) (*T, error) {
+	return BindValues[T](Sources{Query: query})
+}
+
+func BindHeader[T any](header http.Header) (*T, error) {
+	return BindValues[T](Sources{Header: header})
+}
+
+// BindForm
+// binds an application/x-www-form-urlencoded form
+func BindForm[T any](form url.Values) (*T, error) {
+	return BindValues[T](Sources{Form: form})
+}
+
+// BindMultipart
+// binds the values and files of a multipart/form-data form
+func BindMultipart[T any](form *multipart.Form) (*T, error) {
+	return BindValues[T](Sources{Form: form.Value, Files: form.File})
+}
+
+// DecodeValues
+// binds sources into the struct v points to without validation.
+// conversion failures are joined with errors.ErrUnmarshal.
+func DecodeValues(v any, sources Sources) error {
+	getters := []struct {
+		tagName string
+		get     bind.Getter
+	}{
+		{tagName: "path", get: func(key string) []string {
+			if value, ok := sources.Path[key]; ok {
+				return []string{value}
+			}
+			return nil
+		}},
+		{tagName: "query", get: func(key string) []string {
+			return sources.Query[key]
+		}},
+		{tagName: "header", get: func(key string) []string {
+			return sources.Header.Values(key)
+		}},
+		{tagName: "form", get: func(key string) []string {
+			return sources.Form[key]
+		}},
+	}
+
+	for _, getter := range getters {
+		if err := bind.Decode(v, getter.tagName, getter.get); err != nil {
+			return errors.Join(jsonxErr.ErrUnmarshal, err)
+		}
+	}
+
+	return bind.DecodeFiles(v, "form", func(key string) []*multipart.FileHeader {
+		return sources.Files[key]
+	})
+}
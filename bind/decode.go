@@ -0,0 +1,204 @@
+package bind
+
+import (
+	"encoding"
+	"errors"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	textUnmarshalType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Getter
+// every value of key, nil if the key is absent
+type Getter func(key string) []string
+
+// Decode
+// fills the exported fields of the struct dst points to which are tagged `tagName:"key"`.
+// fields without the tag are descended into when they are structs,
+// pointers to a struct already being decoded (type Node struct{ Next *Node }) are not.
+// absent keys leave their fields untouched.
+func Decode(dst any, tagName string, get Getter) error {
+	valueOf := reflect.ValueOf(dst)
+	if valueOf.Kind() != reflect.Pointer || valueOf.IsNil() || valueOf.Elem().Kind() != reflect.Struct {
+		return errors.New("use only struct pointer")
+	}
+
+	return decodeStruct(valueOf.Elem(), tagName, get, map[reflect.Type]bool{})
+}
+
+// decodeStruct
+// path holds the struct types being decoded, from dst down to valueOf
+func decodeStruct(valueOf reflect.Value, tagName string, get Getter, path map[reflect.Type]bool) error {
+	typeOf := valueOf.Type()
+	path[typeOf] = true
+	defer delete(path, typeOf)
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+		if !field.IsExported() && !isEmbeddedStruct(field) {
+			continue
+		}
+
+		key := strings.Split(field.Tag.Get(tagName), ",")[0]
+		if key == "" || key == "-" {
+			if isNestedStruct(field.Type) && key != "-" {
+				if err := decodeNested(valueOf.Field(i), tagName, get, path); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		values := get(key)
+		if len(values) == 0 || IsFileType(field.Type) {
+			continue
+		}
+
+		if err := setValues(valueOf.Field(i), field, values); err != nil {
+			return jsonxErr.NewBindErr(tagName, key, strings.Join(values, ","), err)
+		}
+	}
+
+	return nil
+}
+
+func decodeNested(fieldValue reflect.Value, tagName string, get Getter, path map[reflect.Type]bool) error {
+	if fieldValue.Kind() != reflect.Pointer {
+		return decodeStruct(fieldValue, tagName, get, path)
+	}
+	if path[fieldValue.Type().Elem()] {
+		// a recursive type would be allocated forever
+		return nil
+	}
+
+	// allocate only when something is bound
+	nested := reflect.New(fieldValue.Type().Elem())
+	if !fieldValue.IsNil() {
+		nested.Elem().Set(fieldValue.Elem())
+	}
+	if err := decodeStruct(nested.Elem(), tagName, get, path); err != nil {
+		return err
+	}
+	if !fieldValue.IsNil() || !nested.Elem().IsZero() {
+		fieldValue.Set(nested)
+	}
+
+	return nil
+}
+
+func setValues(fieldValue reflect.Value, field reflect.StructField, values []string) error {
+	if fieldValue.Kind() == reflect.Slice && !isScalar(fieldValue.Type()) {
+		slice := reflect.MakeSlice(fieldValue.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(slice.Index(i), field, value); err != nil {
+				return err
+			}
+		}
+		fieldValue.Set(slice)
+
+		return nil
+	}
+
+	return setValue(fieldValue, field, values[0])
+}
+
+func setValue(fieldValue reflect.Value, field reflect.StructField, value string) error {
+	if fieldValue.Kind() == reflect.Pointer {
+		elem := reflect.New(fieldValue.Type().Elem())
+		if err := setValue(elem.Elem(), field, value); err != nil {
+			return err
+		}
+		fieldValue.Set(elem)
+
+		return nil
+	}
+
+	if fieldValue.Type() == timeType {
+		layout := field.Tag.Get("layout")
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(t))
+
+		return nil
+	}
+
+	if fieldValue.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(int64(d))
+
+		return nil
+	}
+
+	if reflect.PointerTo(fieldValue.Type()).Implements(textUnmarshalType) {
+		return fieldValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetFloat(n)
+	default:
+		return errors.New("unsupported field type " + fieldValue.Type().String())
+	}
+
+	return nil
+}
+
+// isScalar
+// slices decoded from a single value, e.g. []byte through encoding.TextUnmarshaler
+func isScalar(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalType)
+}
+
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != timeType && t != fileType && !reflect.PointerTo(t).Implements(textUnmarshalType)
+}
+
+// isEmbeddedStruct
+// fields of an embedded struct are promoted even when its type is unexported
+func isEmbeddedStruct(field reflect.StructField) bool {
+	return field.Anonymous && field.Type.Kind() == reflect.Struct
+}
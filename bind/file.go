@@ -0,0 +1,186 @@
+package bind
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+var fileType = reflect.TypeOf(File{})
+
+// File
+// an uploaded multipart file bound to a field tagged `form:"avatar"`.
+// fields can be File, *File, []File or []*File.
+type File struct {
+	header *multipart.FileHeader
+}
+
+func NewFile(header *multipart.FileHeader) *File {
+	return &File{header: header}
+}
+
+func (f File) Filename() string {
+	if f.header == nil {
+		return ""
+	}
+
+	return f.header.Filename
+}
+
+func (f File) Size() int64 {
+	if f.header == nil {
+		return 0
+	}
+
+	return f.header.Size
+}
+
+// ContentType
+// the media type declared by the client, without parameters
+func (f File) ContentType() string {
+	if f.header == nil {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(f.header.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
+
+// DetectContentType
+// sniffs the media type from the content, see http.DetectContentType
+func (f File) DetectContentType() (string, error) {
+	file, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+func (f File) Open() (multipart.File, error) {
+	if f.header == nil {
+		return nil, errors.New("no uploaded file")
+	}
+
+	return f.header.Open()
+}
+
+// FileGetter
+// every uploaded file of key, nil if the key is absent
+type FileGetter func(key string) []*multipart.FileHeader
+
+// DecodeFiles
+// fills the File fields of the struct dst points to which are tagged `tagName:"key"`.
+// nested structs and struct pointers are descended into like Decode does.
+func DecodeFiles(dst any, tagName string, get FileGetter) error {
+	valueOf := reflect.ValueOf(dst)
+	if valueOf.Kind() != reflect.Pointer || valueOf.IsNil() || valueOf.Elem().Kind() != reflect.Struct {
+		return errors.New("use only struct pointer")
+	}
+
+	return decodeFileStruct(valueOf.Elem(), tagName, get, map[reflect.Type]bool{})
+}
+
+// decodeFileStruct
+// path holds the struct types being decoded, from dst down to valueOf
+func decodeFileStruct(valueOf reflect.Value, tagName string, get FileGetter, path map[reflect.Type]bool) error {
+	typeOf := valueOf.Type()
+	path[typeOf] = true
+	defer delete(path, typeOf)
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+		if !field.IsExported() && !isEmbeddedStruct(field) {
+			continue
+		}
+
+		fieldValue := valueOf.Field(i)
+		if !IsFileType(field.Type) {
+			if isNestedStruct(field.Type) && field.Tag.Get(tagName) != "-" {
+				if err := decodeNestedFiles(fieldValue, tagName, get, path); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		key := strings.Split(field.Tag.Get(tagName), ",")[0]
+		headers := get(key)
+		if key == "" || key == "-" || len(headers) == 0 {
+			continue
+		}
+
+		setFiles(fieldValue, headers)
+	}
+
+	return nil
+}
+
+func decodeNestedFiles(fieldValue reflect.Value, tagName string, get FileGetter, path map[reflect.Type]bool) error {
+	if fieldValue.Kind() != reflect.Pointer {
+		return decodeFileStruct(fieldValue, tagName, get, path)
+	}
+	if path[fieldValue.Type().Elem()] {
+		// a recursive type would be allocated forever
+		return nil
+	}
+
+	// allocate only when a file is bound
+	nested := reflect.New(fieldValue.Type().Elem())
+	if !fieldValue.IsNil() {
+		nested.Elem().Set(fieldValue.Elem())
+	}
+	if err := decodeFileStruct(nested.Elem(), tagName, get, path); err != nil {
+		return err
+	}
+	if !fieldValue.IsNil() || !nested.Elem().IsZero() {
+		fieldValue.Set(nested)
+	}
+
+	return nil
+}
+
+func setFiles(fieldValue reflect.Value, headers []*multipart.FileHeader) {
+	t := fieldValue.Type()
+
+	switch {
+	case t == fileType:
+		fieldValue.Set(reflect.ValueOf(*NewFile(headers[0])))
+	case t == reflect.PointerTo(fileType):
+		fieldValue.Set(reflect.ValueOf(NewFile(headers[0])))
+	case t.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(t, len(headers), len(headers))
+		for i, header := range headers {
+			setFiles(slice.Index(i), []*multipart.FileHeader{header})
+		}
+		fieldValue.Set(slice)
+	}
+}
+
+// IsFileType
+// File, *File, []File and []*File
+func IsFileType(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t == fileType
+}
@@ -0,0 +1,218 @@
+package jsonx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aivyss/jsonx/definitions"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/tag"
+	"reflect"
+	"regexp"
+	"sort"
+)
+
+// Check
+// walks T once with the rules of tagValidation and reports every misconfigured tag.
+// the returned error joins one *errors.TagError per problem.
+func Check[T any]() error {
+	typeOf := reflect.TypeOf(new(T)).Elem()
+	if typeOf.Kind() != reflect.Struct {
+		return errors.New("use only struct type")
+	}
+
+	c := &typeChecker{visited: map[reflect.Type]bool{}}
+	c.check(typeOf)
+
+	return errors.Join(c.problems...)
+}
+
+// MustRegisterType
+// panics if Check[T] fails. call it at startup for every request/response type.
+func MustRegisterType[T any]() {
+	if err := Check[T](); err != nil {
+		panic(err)
+	}
+
+	typeOf := reflect.TypeOf(new(T)).Elem()
+	registeredTypes[typeOf] = true
+}
+
+type typeChecker struct {
+	visited  map[reflect.Type]bool
+	problems []error
+}
+
+func (c *typeChecker) check(typeOf reflect.Type) {
+	if c.visited[typeOf] {
+		return
+	}
+	c.visited[typeOf] = true
+
+	for _, plan := range planOf(typeOf) {
+		field := plan.field
+
+		if !field.IsExported() {
+			if plan.hasTags() {
+				c.report(typeOf, field, "annotation", "unexported field is never validated")
+			}
+			if !plan.isVisible() {
+				continue
+			}
+		}
+
+		if plan.hasTags() && !isValidatableKind(field.Type) {
+			c.report(typeOf, field, "annotation", fmt.Sprintf("unsupported field type %s", field.Type))
+		}
+
+		c.checkAnnotations(typeOf, plan)
+		c.checkPattern(typeOf, plan)
+		c.checkFieldErr(typeOf, plan)
+		c.checkDefault(typeOf, plan)
+
+		if plan.nested != nil {
+			c.check(plan.nested)
+			continue
+		}
+
+		if elem := containerElemStruct(field.Type); elem != nil && hasValidationTags(elem, map[reflect.Type]bool{}) {
+			c.report(typeOf, field, "annotation", fmt.Sprintf("unsupported container type %s: elements are not validated", field.Type))
+		}
+	}
+}
+
+func (c *typeChecker) checkAnnotations(typeOf reflect.Type, plan fieldPlan) {
+	if plan.annotations == "" {
+		return
+	}
+
+	for _, annoStr := range tag.SplitAnnotationTag(plan.annotations) {
+		annotation, err := definitions.ConvertToAnnotation(annoStr)
+		if err != nil {
+			c.report(typeOf, plan.field, "annotation", fmt.Sprintf("@%s: %s", annoStr, err))
+			continue
+		}
+
+		if !annotation.Supports(plan.field.Type) {
+			c.report(typeOf, plan.field, "annotation", fmt.Sprintf("@%s does not support %s", annotation.Name(), plan.field.Type))
+		}
+	}
+}
+
+func (c *typeChecker) checkPattern(typeOf reflect.Type, plan fieldPlan) {
+	if plan.pattern == "" {
+		return
+	}
+
+	if _, err := regexp.Compile(plan.pattern); err != nil {
+		c.report(typeOf, plan.field, "pattern", fmt.Sprintf("wrong regular expression: %s", err))
+	}
+
+	if t := plan.field.Type; t != reflect.TypeOf("") && t != reflect.TypeOf(new(string)) {
+		c.report(typeOf, plan.field, "pattern", fmt.Sprintf("pattern does not support %s", t))
+	}
+}
+
+func (c *typeChecker) checkFieldErr(typeOf reflect.Type, plan fieldPlan) {
+	if plan.fieldErr == "" {
+		return
+	}
+
+	mapping, fallback, err := parseFieldErrTag(plan.fieldErr)
+	if err != nil {
+		c.report(typeOf, plan.field, "fieldErr", err.Error())
+		return
+	}
+
+	annotations := make([]string, 0, len(mapping))
+	for annotation := range mapping {
+		annotations = append(annotations, annotation)
+	}
+	sort.Strings(annotations)
+
+	names := make([]string, 0, len(mapping)+1)
+	if fallback != "" {
+		names = append(names, fallback)
+	}
+	for _, annotation := range annotations {
+		if !c.isUsedAnnotation(plan, annotation) {
+			c.report(typeOf, plan.field, "fieldErr", fmt.Sprintf("%s is not used on the field", annotation))
+		}
+		names = append(names, mapping[annotation])
+	}
+
+	for _, name := range names {
+		if _, ok := fieldErrMap[name]; !ok {
+			c.report(typeOf, plan.field, "fieldErr", fmt.Sprintf("unknown field error %q", name))
+		}
+	}
+}
+
+func (c *typeChecker) checkDefault(typeOf reflect.Type, plan fieldPlan) {
+	if plan.defaultValue == "" {
+		return
+	}
+
+	if _, err := defaultValueOf(plan.field.Type, plan.defaultValue); err != nil {
+		c.report(typeOf, plan.field, "default", err.Error())
+	}
+}
+
+// isUsedAnnotation
+// whether a fieldErr mapping key names an annotation of the field or its pattern
+func (c *typeChecker) isUsedAnnotation(plan fieldPlan, annotation string) bool {
+	if annotation == "pattern" {
+		return plan.pattern != ""
+	}
+
+	for _, annoStr := range tag.SplitAnnotationTag(plan.annotations) {
+		if a, err := definitions.ConvertToAnnotation(annoStr); err == nil && a.Name() == annotation {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *typeChecker) report(typeOf reflect.Type, field reflect.StructField, tagName, msg string) {
+	c.problems = append(c.problems, jsonxErr.NewTagErr(typeOf.String(), field.Name, tagName, msg))
+}
+
+// isValidatableKind
+// channels, functions, complex numbers and unsafe pointers can not carry validation tags
+func isValidatableKind(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return false
+	default:
+		return true
+	}
+}
+
+// containerElemStruct
+// returns the struct element type of a slice, array or map field
+func containerElemStruct(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return nestedStructType(t.Elem())
+	default:
+		return nil
+	}
+}
+
+func hasValidationTags(typeOf reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[typeOf] {
+		return false
+	}
+	visited[typeOf] = true
+
+	for _, plan := range planOf(typeOf) {
+		if plan.hasTags() {
+			return true
+		}
+		if plan.nested != nil && hasValidationTags(plan.nested, visited) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,15 @@
+package jsonx
+
+import "github.com/aivyss/jsonx/civil"
+
+// Date
+// a calendar date encoded as "2006-01-02", supports @Min, @Max, @Between and the @Future family
+type Date = civil.Date
+
+// TimeOfDay
+// a wall clock time encoded as "15:04:05", supports @Min, @Max and @Between
+type TimeOfDay = civil.TimeOfDay
+
+// Duration
+// a time.Duration encoded as "1h30m0s" and decoded from "1h30m" or nanoseconds, supports @Min, @Max and @Between
+type Duration = civil.Duration
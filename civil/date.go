@@ -0,0 +1,125 @@
+package civil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date
+// a calendar date without time and location, encoded as "2006-01-02" in JSON and the zero Date as null.
+// billing and birth dates do not move when the time zone changes.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf
+// the date of t in its location
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate
+// "2024-02-29" -> Date{2024, February, 29}
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("wrong date %q: %w", s, err)
+	}
+
+	return DateOf(t), nil
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsValid
+// reports whether the date exists, 2023-02-29 does not
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In
+// the midnight that starts the date in loc
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays
+// the date n days later, n can be negative
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Compare
+// -1, 0 or +1 when d is before, equal to or after other
+func (d Date) Compare(other Date) int {
+	return d.In(time.UTC).Compare(other.In(time.UTC))
+}
+
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	if !d.IsValid() {
+		return nil, errors.New("invalid date " + d.String())
+	}
+
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(data []byte) error {
+	date, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = date
+
+	return nil
+}
+
+// MarshalJSON
+// null for the zero Date, structs are never empty for omitempty and 0000-00-00 is no date
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	text, err := d.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("wrong date %s: %w", data, err)
+	}
+
+	return d.UnmarshalText([]byte(s))
+}
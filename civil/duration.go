@@ -0,0 +1,52 @@
+package civil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Duration
+// a time.Duration encoded as "1h30m0s" in JSON.
+// it decodes from strings such as "1h30m" and from integer nanoseconds like time.Duration.
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(data []byte) error {
+	duration, err := time.ParseDuration(string(data))
+	if err != nil {
+		return fmt.Errorf("wrong duration %q: %w", data, err)
+	}
+	*d = Duration(duration)
+
+	return nil
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return d.UnmarshalText([]byte(s))
+	}
+
+	var nanoseconds int64
+	if err := json.Unmarshal(data, &nanoseconds); err != nil {
+		return fmt.Errorf("wrong duration %s: %w", data, err)
+	}
+	*d = Duration(nanoseconds)
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package civil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timeOfDayLayouts
+// 15:04, 15:04:05 and 15:04:05 with up to nine fractional digits
+var timeOfDayLayouts = []string{"15:04", "15:04:05", "15:04:05.999999999"}
+
+// TimeOfDay
+// a wall clock time without date and location, encoded as "15:04:05" in JSON
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOfDayOf
+// the wall clock time of t in its location
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	hour, minute, second := t.Clock()
+	return TimeOfDay{Hour: hour, Minute: minute, Second: second, Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay
+// "09:30", "09:30:15" or "09:30:15.5"
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	// time.Parse accepts a single digit hour for 15
+	if len(s) < 5 || s[2] != ':' {
+		return TimeOfDay{}, fmt.Errorf("wrong time of day %q: expected 15:04", s)
+	}
+
+	layout := timeOfDayLayouts[0]
+	switch {
+	case strings.Contains(s, "."):
+		layout = timeOfDayLayouts[2]
+	case strings.Count(s, ":") == 2:
+		layout = timeOfDayLayouts[1]
+	}
+
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("wrong time of day %q: %w", s, err)
+	}
+
+	return TimeOfDayOf(t), nil
+}
+
+// String
+// 09:30:00, fractional seconds are kept: 09:30:15.5
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond == 0 {
+		return s
+	}
+
+	return s + strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+}
+
+func (t TimeOfDay) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 &&
+		t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 &&
+		t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// On
+// the time of day on the date in loc
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// Compare
+// -1, 0 or +1 when t is before, equal to or after other
+func (t TimeOfDay) Compare(other TimeOfDay) int {
+	switch a, b := t.sinceMidnight(), other.sinceMidnight(); {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (t TimeOfDay) Before(other TimeOfDay) bool {
+	return t.Compare(other) < 0
+}
+
+func (t TimeOfDay) After(other TimeOfDay) bool {
+	return t.Compare(other) > 0
+}
+
+func (t TimeOfDay) sinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
+
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	if !t.IsValid() {
+		return nil, fmt.Errorf("invalid time of day %02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	}
+
+	return []byte(t.String()), nil
+}
+
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	timeOfDay, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+	*t = timeOfDay
+
+	return nil
+}
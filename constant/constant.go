@@ -1,3 +1,5 @@
 package constant
 
 const EmailRegex = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+
+const FrameworkName = "jsonx"
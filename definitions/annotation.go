@@ -2,54 +2,160 @@ package definitions
 
 import (
 	"errors"
-	"github.com/aivyss/jsonx/constant"
-	"github.com/aivyss/typex"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
 	"github.com/aivyss/typex/types"
+	"net/netip"
 	"reflect"
-	"regexp"
+	"sort"
 	"strings"
-	"time"
 )
 
 var (
 	defaultAnnotations = map[string]Annotation{
-		"NotBlank":         {name: "NotBlank", Validate: notBlank},
-		"NotEmpty":         {name: "NotEmpty", Validate: notEmpty},
-		"Required":         {name: "Required", Validate: required},
-		"Email":            {name: "Email", Validate: email},
-		"NotContainsNil":   {name: "NotContainsNil", Validate: notContainsNil},
-		"NotContainsEmpty": {name: "NotContainsEmpty", Validate: notContainsEmpty},
-		"NotContainsBlank": {name: "NotContainsBlank", Validate: notContainsBlank},
-		"Positive":         {name: "Positive", Validate: positive},
-		"Negative":         {name: "Negative", Validate: negative},
-		"PositiveOrZero":   {name: "PositiveOrZero", Validate: positiveOrZero},
-		"NegativeOrZero":   {name: "NegativeOrZero", Validate: negativeOrZero},
-		"Future":           {name: "Future", Validate: future},
-		"Present":          {name: "Present", Validate: present},
-		"Past":             {name: "Past", Validate: past},
-		"FutureOrPresent":  {name: "FutureOrPresent", Validate: futureOrPresent},
-		"PastOrPresent":    {name: "PastOrPresent", Validate: pastOrPresent},
+		"NotBlank":         {name: "NotBlank", Validate: notBlank, supports: isStringType},
+		"NotEmpty":         {name: "NotEmpty", Validate: notEmpty, supports: isStringType},
+		"Required":         {name: "Required", Validate: required, supports: isNillableType},
+		"Email":            {name: "Email", bind: bindEmail, supports: isStringOrContainer},
+		"NotContainsNil":   {name: "NotContainsNil", Validate: notContainsNil, supports: isSliceOf(isNillableType)},
+		"NotContainsEmpty": {name: "NotContainsEmpty", Validate: notContainsEmpty, supports: isSliceOf(isStringType)},
+		"NotContainsBlank": {name: "NotContainsBlank", Validate: notContainsBlank, supports: isSliceOf(isStringType)},
+		"Positive":         {name: "Positive", Validate: positive, supports: anyOf(isNumberType, isDurationType)},
+		"Negative":         {name: "Negative", Validate: negative, supports: anyOf(isNumberType, isDurationType)},
+		"PositiveOrZero":   {name: "PositiveOrZero", Validate: positiveOrZero, supports: anyOf(isNumberType, isDurationType)},
+		"NegativeOrZero":   {name: "NegativeOrZero", Validate: negativeOrZero, supports: anyOf(isNumberType, isDurationType)},
+		"Future":           {name: "Future", bind: bindTemporal("Future", isAfter, "not future time"), supportsArgs: supportsTemporal},
+		"Present":          {name: "Present", bind: bindTemporal("Present", isSame, "not present time"), supportsArgs: supportsTemporal},
+		"Past":             {name: "Past", bind: bindTemporal("Past", isBefore, "not past time"), supportsArgs: supportsTemporal},
+		"FutureOrPresent":  {name: "FutureOrPresent", bind: bindTemporal("FutureOrPresent", isNotBefore, "past time"), supportsArgs: supportsTemporal},
+		"PastOrPresent":    {name: "PastOrPresent", bind: bindTemporal("PastOrPresent", isNotAfter, "future time"), supportsArgs: supportsTemporal},
+		"PastWithin":       {name: "PastWithin", bind: bindPastWithin, supportsArgs: supportsWindow(1)},
+		"FutureAfter":      {name: "FutureAfter", bind: bindFutureAfter, supportsArgs: supportsWindow(1)},
+		"After":            {name: "After", bind: bindAfter, supportsArgs: supportsWindow(1)},
+		"Before":           {name: "Before", bind: bindBefore, supportsArgs: supportsWindow(1)},
+		"Between":          {name: "Between", bind: bindBetween, supportsArgs: supportsBetween},
+		"Min":              {name: "Min", bind: bindMin, supportsArgs: supportsBound},
+		"Max":              {name: "Max", bind: bindMax, supportsArgs: supportsBound},
+		"Length":           {name: "Length", bind: bindLength, supports: hasLength},
+		"MaxBytes":         {name: "MaxBytes", bind: bindMaxBytes, supports: anyOf(isFileType, isStringType, isByteSlice)},
+		"ContentType":      {name: "ContentType", bind: bindContentType, supports: isFileType},
+		"Enum":             {name: "Enum", bind: bindEnum, supports: anyOf(isStringType, isNumberType)},
+		"UUID":             {name: "UUID", bind: bindUUID, supports: isStringOrContainer},
+		"ULID":             {name: "ULID", Validate: ulid, supports: isStringOrContainer},
+		"Hex":              {name: "Hex", Validate: hex, supports: isStringOrContainer},
+		"Base64":           {name: "Base64", bind: bindBase64, supports: isStringOrContainer},
+		"Semver":           {name: "Semver", Validate: semver, supports: isStringOrContainer},
+		"URL":              {name: "URL", bind: bindURL, supports: isStringOrContainer},
+		"URI":              {name: "URI", Validate: uri, supports: isStringOrContainer},
+		"Hostname":         {name: "Hostname", Validate: hostname, supports: isStringOrContainer},
+		"IP":               {name: "IP", bind: bindIP("IP", netip.Addr.IsValid), supports: isStringOrContainer},
+		"IPv4":             {name: "IPv4", bind: bindIP("IPv4", netip.Addr.Is4), supports: isStringOrContainer},
+		"IPv6":             {name: "IPv6", bind: bindIP("IPv6", netip.Addr.Is6), supports: isStringOrContainer},
+		"CIDR":             {name: "CIDR", Validate: cidr, supports: isStringOrContainer},
+		"MAC":              {name: "MAC", Validate: mac, supports: isStringOrContainer},
+		"Port":             {name: "Port", Validate: port, supports: anyOf(isIntegerType, isStringOrContainer)},
+		"DateTime":         {name: "DateTime", bind: bindDateTime, supports: isStringOrContainer},
+		"Date":             {name: "Date", Validate: date, supports: isStringOrContainer},
+		"RFC3339":          {name: "RFC3339", Validate: rfc3339, supports: isStringOrContainer},
+		"Timezone":         {name: "Timezone", Validate: timezone, supports: isStringOrContainer},
+		"Digits":           {name: "Digits", bind: bindDigits, supports: anyOf(isNumberType, isStringOrContainer)},
+		"Scale":            {name: "Scale", bind: bindScale, supports: anyOf(isNumberType, isStringOrContainer)},
+		"MultipleOf":       {name: "MultipleOf", bind: bindMultipleOf, supports: anyOf(isNumberType, isStringOrContainer)},
+		"Currency":         {name: "Currency", bind: bindCurrency, supportsArgs: supportsCurrency},
 	}
 	customAnnotations = map[string]Annotation{}
 )
 
 type Annotation struct {
 	name     string
+	args     []string
+	params   map[string]string
 	Validate AnnotationValidate
+	supports func(t reflect.Type) bool
+	// bind builds Validate from the arguments of annotations such as @Min(1)
+	bind func(args []string) (AnnotationValidate, map[string]string, error)
+	// supportsArgs builds supports from the arguments, e.g. @Future(RFC3339) also supports strings
+	supportsArgs func(args []string) func(t reflect.Type) bool
 }
 
+func (a *Annotation) Name() string {
+	return a.name
+}
+
+func (a *Annotation) Args() []string {
+	return a.args
+}
+
+// Params
+// named arguments used by message templates, e.g. {"min": "1"} for @Min(1)
+func (a *Annotation) Params() map[string]string {
+	return a.params
+}
+
+// Supports
+// reports whether the annotation can validate a field of type t.
+// custom annotations accept every type.
+func (a *Annotation) Supports(t reflect.Type) bool {
+	if a.supports == nil {
+		return true
+	}
+
+	return a.supports(t)
+}
+
+// ConvertToAnnotation
+// "Min(1)" -> @Min bound to the argument 1
 func ConvertToAnnotation(v string) (*Annotation, error) {
-	anno, defaultOk := defaultAnnotations[v]
-	if defaultOk {
-		return &anno, nil
+	name, args, err := parseAnnotation(v)
+	if err != nil {
+		return nil, err
+	}
+
+	anno, ok := defaultAnnotations[name]
+	if !ok {
+		anno, ok = customAnnotations[name]
+	}
+	if !ok {
+		return nil, errors.New("invalid annotation")
 	}
 
-	anno, customOk := customAnnotations[v]
-	if customOk {
-		return &anno, nil
+	return anno.withArgs(args)
+}
+
+func (a Annotation) withArgs(args []string) (*Annotation, error) {
+	if a.bind == nil {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("@%s takes no arguments", a.name)
+		}
+
+		return &a, nil
 	}
 
-	return nil, errors.New("invalid annotation")
+	validate, params, err := a.bind(args)
+	if err != nil {
+		return nil, fmt.Errorf("@%s %w", a.name, err)
+	}
+
+	a.args = args
+	a.params = params
+	a.Validate = validate
+	if a.supportsArgs != nil {
+		a.supports = a.supportsArgs(args)
+	}
+
+	return &a, nil
+}
+
+// DefaultAnnotationNames
+// names of the built-in annotations
+func DefaultAnnotationNames() []string {
+	names := make([]string, 0, len(defaultAnnotations))
+	for name := range defaultAnnotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
 }
 
 func RegisterCustomAnnotation(annotationName string, validateFunc AnnotationValidate) error {
@@ -71,110 +177,69 @@ func RegisterCustomAnnotation(annotationName string, validateFunc AnnotationVali
 // notEmpty
 // @NotEmpty
 func notEmpty(v any) error {
-	var err error = nil
-
-	switch v.(type) {
+	switch value := v.(type) {
 	case *string:
-		if types.IsNil(v) {
-			err = errors.New("@NotEmpty nil value")
-		} else {
-			if *(v.(*string)) == "" {
-				err = errors.New("@NotEmpty empty value")
-			}
+		if value == nil {
+			return nilErr("NotEmpty", v)
+		}
+		if *value == "" {
+			return emptyErr("NotEmpty", v)
 		}
+		return nil
 	case string:
-		if v.(string) == "" {
-			err = errors.New("@NotEmpty empty value")
+		if value == "" {
+			return emptyErr("NotEmpty", v)
 		}
+		return nil
 	default:
-		err = errors.New("@NotEmpty wrong type")
+		return wrongTypeErr("NotEmpty", v)
 	}
-
-	return err
 }
 
 // notBlank
 // @NotBlank
 func notBlank(v any) error {
-	var err error = nil
-
-	switch v.(type) {
+	switch value := v.(type) {
 	case *string:
-		if types.IsNil(v) {
-			err = errors.New("@NotBlank nil value")
-		} else {
-			if strings.TrimSpace(*(v.(*string))) == "" {
-				err = errors.New("@NotBlank blank value")
-			}
+		if value == nil {
+			return nilErr("NotBlank", v)
+		}
+		if strings.TrimSpace(*value) == "" {
+			return jsonxErr.NewAnnotationErr("NotBlank", jsonxErr.ReasonBlank, v, "blank value")
 		}
+		return nil
 	case string:
-		if strings.TrimSpace(v.(string)) == "" {
-			err = errors.New("@NotBlank empty value")
+		if strings.TrimSpace(value) == "" {
+			return jsonxErr.NewAnnotationErr("NotBlank", jsonxErr.ReasonBlank, v, "blank value")
 		}
+		return nil
 	default:
-		err = errors.New("@NotBlank wrong type")
+		return wrongTypeErr("NotBlank", v)
 	}
-
-	return err
 }
 
 // requried
 // @Required
 func required(v any) error {
 	if types.IsNil(v) {
-		return errors.New("@Required")
+		return nilErr("Required", v)
 	}
 
 	return nil
 }
 
-// email
-// @Email
-func email(v any) error {
-	var err error = nil
-
-	switch v.(type) {
-	case *string:
-		if types.IsNil(v) {
-			err = errors.New("@Email nil value")
-		} else {
-			matched, err := regexp.MatchString(constant.EmailRegex, *(v.(*string)))
-			if err != nil {
-				err = errors.Join(err, errors.New("@Email not email format"))
-			}
-
-			if !matched {
-				err = errors.New("@Email not email format")
-			}
-		}
-	case string:
-		matched, err := regexp.MatchString(constant.EmailRegex, v.(string))
-		if err != nil {
-			err = errors.Join(err, errors.New("@Email not email format"))
-		}
-
-		if !matched {
-			err = errors.New("@Email not email format")
-		}
-	default:
-		err = errors.New("@Email wrong type")
-	}
-
-	return err
-}
-
 // notContainsNil
 // @NotContainsNil
 func notContainsNil(v any) error {
 	valueOf := reflect.ValueOf(v)
 	if valueOf.Kind() != reflect.Slice {
-		return errors.New("@NotContainsNil not slice type")
+		return wrongTypeErr("NotContainsNil", v)
 	}
 
 	for i := 0; i < valueOf.Len(); i++ {
 		elem := valueOf.Index(i)
 		if elem.IsNil() {
-			return errors.New("@NotContainsNil nil value is not allowed")
+			return jsonxErr.NewAnnotationErr("NotContainsNil", jsonxErr.ReasonNil, elem.Interface(), fmt.Sprintf("nil value at index %d", i))
 		}
 	}
 
@@ -184,35 +249,28 @@ func notContainsNil(v any) error {
 // notContainsEmpty
 // @NotContainsEmpty
 func notContainsEmpty(v any) error {
-	valueOf := reflect.ValueOf(v)
-	if valueOf.Kind() != reflect.Slice {
-		return errors.New("@NotContainsEmpty not slice type")
-	}
-
-	for i := 0; i < valueOf.Len(); i++ {
-		elem := valueOf.Index(i)
-		err := notEmpty(elem.Interface())
-		if err != nil {
-			return errors.Join(err, errors.New("@NotContainsEmpty"))
-		}
-	}
-
-	return nil
+	return eachElement(v, "NotContainsEmpty", notEmpty)
 }
 
 // notContainsBlank
 // @NotContainsBlank
 func notContainsBlank(v any) error {
+	return eachElement(v, "NotContainsBlank", notBlank)
+}
+
+// eachElement
+// checks every element of a slice, the reason of the element error is kept
+func eachElement(v any, annotation string, check AnnotationValidate) error {
 	valueOf := reflect.ValueOf(v)
 	if valueOf.Kind() != reflect.Slice {
-		return errors.New("@NotContainsBlank not slice type")
+		return wrongTypeErr(annotation, v)
 	}
 
 	for i := 0; i < valueOf.Len(); i++ {
-		elem := valueOf.Index(i)
-		err := notBlank(elem.Interface())
-		if err != nil {
-			return errors.Join(err, errors.New("@NotContainsBlank"))
+		elem := valueOf.Index(i).Interface()
+		var annotationErr *jsonxErr.AnnotationError
+		if err := check(elem); errors.As(err, &annotationErr) {
+			return jsonxErr.NewAnnotationErr(annotation, annotationErr.Reason, elem, fmt.Sprintf("%s at index %d", annotationErr.Detail, i))
 		}
 	}
 
@@ -222,482 +280,41 @@ func notContainsBlank(v any) error {
 // positive
 // @Positive
 func positive(v any) error {
-	nilErr := errors.New("@Positive nil value")
-	notPositiveErr := errors.New("@Positive not positive value")
-	var err error = nil
-
-	typex.Opt(v).IfPresent(func(v any) {
-		switch v.(type) {
-		case int8:
-			if v.(int8) <= 0 {
-				err = notPositiveErr
-			}
-		case int16:
-			if v.(int16) <= 0 {
-				err = notPositiveErr
-			}
-		case int32:
-			if v.(int32) <= 0 {
-				err = notPositiveErr
-			}
-		case int64:
-			if v.(int64) <= 0 {
-				err = notPositiveErr
-			}
-		case int:
-			if v.(int) <= 0 {
-				err = notPositiveErr
-			}
-		case float32:
-			if v.(float32) <= 0 {
-				err = notPositiveErr
-			}
-		case float64:
-			if v.(float64) <= 0 {
-				err = notPositiveErr
-			}
-
-		case *int8:
-			i := v.(*int8)
-			if *i <= 0 {
-				err = notPositiveErr
-			}
-		case *int16:
-			i := v.(*int16)
-			if *i <= 0 {
-				err = notPositiveErr
-			}
-		case *int32:
-			i := v.(*int32)
-			if *i <= 0 {
-				err = notPositiveErr
-			}
-		case *int64:
-			i := v.(*int64)
-			if *i <= 0 {
-				err = notPositiveErr
-			}
-		case *int:
-			i := v.(*int)
-			if *i <= 0 {
-				err = notPositiveErr
-			}
-		case *float32:
-			i := v.(*float32)
-			if *i <= 0 {
-				err = notPositiveErr
-			}
-		case *float64:
-			i := v.(*float64)
-			if *i <= 0 {
-				err = notPositiveErr
-			}
-		default:
-			err = errors.New("@Positive not number type")
-		}
-	}).ElseDo(func() {
-		err = nilErr
-	})
-
-	return err
+	return checkSign(v, "Positive", func(sign int) bool { return sign > 0 }, "not positive value")
 }
 
 // positiveOrZero
 // @PositiveOrZero
 func positiveOrZero(v any) error {
-	nilErr := errors.New("@PositiveOrZero nil value")
-	notPositiveErr := errors.New("@PositiveOrZero negative value")
-	var err error = nil
-
-	typex.Opt(v).IfPresent(func(v any) {
-		switch v.(type) {
-		case int8:
-			if v.(int8) < 0 {
-				err = notPositiveErr
-			}
-		case int16:
-			if v.(int16) < 0 {
-				err = notPositiveErr
-			}
-		case int32:
-			if v.(int32) < 0 {
-				err = notPositiveErr
-			}
-		case int64:
-			if v.(int64) < 0 {
-				err = notPositiveErr
-			}
-		case int:
-			if v.(int) < 0 {
-				err = notPositiveErr
-			}
-		case float32:
-			if v.(float32) < 0 {
-				err = notPositiveErr
-			}
-		case float64:
-			if v.(float64) < 0 {
-				err = notPositiveErr
-			}
-
-		case *int8:
-			i := v.(*int8)
-			if *i < 0 {
-				err = notPositiveErr
-			}
-		case *int16:
-			i := v.(*int16)
-			if *i < 0 {
-				err = notPositiveErr
-			}
-		case *int32:
-			i := v.(*int32)
-			if *i < 0 {
-				err = notPositiveErr
-			}
-		case *int64:
-			i := v.(*int64)
-			if *i < 0 {
-				err = notPositiveErr
-			}
-		case *int:
-			i := v.(*int)
-			if *i < 0 {
-				err = notPositiveErr
-			}
-		case *float32:
-			i := v.(*float32)
-			if *i < 0 {
-				err = notPositiveErr
-			}
-		case *float64:
-			i := v.(*float64)
-			if *i < 0 {
-				err = notPositiveErr
-			}
-		default:
-			err = errors.New("@PositiveOrZero not number type")
-		}
-	}).ElseDo(func() {
-		err = nilErr
-	})
-
-	return err
+	return checkSign(v, "PositiveOrZero", func(sign int) bool { return sign >= 0 }, "negative value")
 }
 
 // negative
 // @Negative
 func negative(v any) error {
-	nilErr := errors.New("@Negative nil value")
-	notNegativeErr := errors.New("@Negative not negative value")
-	var err error = nil
-
-	typex.Opt(v).IfPresent(func(v any) {
-		switch v.(type) {
-		case int8:
-			if v.(int8) >= 0 {
-				err = notNegativeErr
-			}
-		case int16:
-			if v.(int16) >= 0 {
-				err = notNegativeErr
-			}
-		case int32:
-			if v.(int32) >= 0 {
-				err = notNegativeErr
-			}
-		case int64:
-			if v.(int64) >= 0 {
-				err = notNegativeErr
-			}
-		case int:
-			if v.(int) >= 0 {
-				err = notNegativeErr
-			}
-		case float32:
-			if v.(float32) >= 0 {
-				err = notNegativeErr
-			}
-		case float64:
-			if v.(float64) >= 0 {
-				err = notNegativeErr
-			}
-
-		case *int8:
-			i := v.(*int8)
-			if *i >= 0 {
-				err = notNegativeErr
-			}
-		case *int16:
-			i := v.(*int16)
-			if *i >= 0 {
-				err = notNegativeErr
-			}
-		case *int32:
-			i := v.(*int32)
-			if *i >= 0 {
-				err = notNegativeErr
-			}
-		case *int64:
-			i := v.(*int64)
-			if *i >= 0 {
-				err = notNegativeErr
-			}
-		case *int:
-			i := v.(*int)
-			if *i >= 0 {
-				err = notNegativeErr
-			}
-		case *float32:
-			i := v.(*float32)
-			if *i >= 0 {
-				err = notNegativeErr
-			}
-		case *float64:
-			i := v.(*float64)
-			if *i >= 0 {
-				err = notNegativeErr
-			}
-		default:
-			err = errors.New("@Negative not number type")
-		}
-	}).ElseDo(func() {
-		err = nilErr
-	})
-
-	return err
+	return checkSign(v, "Negative", func(sign int) bool { return sign < 0 }, "not negative value")
 }
 
 // negativeOrZero
 // @NegativeOrZero
 func negativeOrZero(v any) error {
-	nilErr := errors.New("@NegativeOrZero nil value")
-	notNegativeErr := errors.New("@NegativeOrZero positive value")
-	var err error = nil
-
-	typex.Opt(v).IfPresent(func(v any) {
-		switch v.(type) {
-		case int8:
-			if v.(int8) > 0 {
-				err = notNegativeErr
-			}
-		case int16:
-			if v.(int16) > 0 {
-				err = notNegativeErr
-			}
-		case int32:
-			if v.(int32) > 0 {
-				err = notNegativeErr
-			}
-		case int64:
-			if v.(int64) > 0 {
-				err = notNegativeErr
-			}
-		case int:
-			if v.(int) > 0 {
-				err = notNegativeErr
-			}
-		case float32:
-			if v.(float32) > 0 {
-				err = notNegativeErr
-			}
-		case float64:
-			if v.(float64) > 0 {
-				err = notNegativeErr
-			}
-
-		case *int8:
-			i := v.(*int8)
-			if *i > 0 {
-				err = notNegativeErr
-			}
-		case *int16:
-			i := v.(*int16)
-			if *i > 0 {
-				err = notNegativeErr
-			}
-		case *int32:
-			i := v.(*int32)
-			if *i > 0 {
-				err = notNegativeErr
-			}
-		case *int64:
-			i := v.(*int64)
-			if *i > 0 {
-				err = notNegativeErr
-			}
-		case *int:
-			i := v.(*int)
-			if *i > 0 {
-				err = notNegativeErr
-			}
-		case *float32:
-			i := v.(*float32)
-			if *i > 0 {
-				err = notNegativeErr
-			}
-		case *float64:
-			i := v.(*float64)
-			if *i > 0 {
-				err = notNegativeErr
-			}
-		default:
-			err = errors.New("@NegativeOrZero not number type")
-		}
-	}).ElseDo(func() {
-		err = nilErr
-	})
-
-	return err
+	return checkSign(v, "NegativeOrZero", func(sign int) bool { return sign <= 0 }, "positive value")
 }
 
-// future
-// @Future
-func future(v any) error {
-	notFutureErr := errors.New("@Future not future time")
-	var err error = nil
-	typex.Opt(v).IfPresent(func(v any) {
-		now := time.Now()
-
-		switch v.(type) {
-		case time.Time:
-			t := v.(time.Time)
-			if t.Before(now) || equal(t, now) {
-				err = notFutureErr
-			}
-		case *time.Time:
-			t := v.(*time.Time)
-			if t.Before(now) || equal(*t, now) {
-				err = notFutureErr
-			}
-		default:
-			err = errors.New("@Future wrong type")
-		}
-	}).ElseDo(func() {
-		err = errors.New("@Future nil value")
-	})
-
-	return err
-}
-
-// futureOrPresent
-// @FutureOrPresent
-func futureOrPresent(v any) error {
-	notFutureOrPresentErr := errors.New("@FutureOrPresent past time")
-	var err error = nil
-
-	typex.Opt(v).IfPresent(func(v any) {
-		now := time.Now()
-
-		switch v.(type) {
-		case time.Time:
-			t := v.(time.Time)
-			if !t.After(now) && !equal(t, now) {
-				err = notFutureOrPresentErr
-			}
-		case *time.Time:
-			t := v.(*time.Time)
-			if !t.After(now) && !equal(*t, now) {
-				err = notFutureOrPresentErr
-			}
-		default:
-			err = errors.New("@FutureOrPresent wrong type")
-		}
-	}).ElseDo(func() {
-		err = errors.New("@FutureOrPresent nil value")
-	})
-
-	return err
-}
-
-// pastOrPresent
-// @PastOrPresent
-func pastOrPresent(v any) error {
-	notPastOrPresentErr := errors.New("@PastOrPresent future time")
-	var err error = nil
-
-	typex.Opt(v).IfPresent(func(v any) {
-		now := time.Now()
-
-		switch v.(type) {
-		case time.Time:
-			t := v.(time.Time)
-			if !t.Before(now) && !equal(t, now) {
-				err = notPastOrPresentErr
-			}
-		case *time.Time:
-			t := v.(*time.Time)
-			if !t.Before(now) && !equal(*t, now) {
-				err = notPastOrPresentErr
-			}
-		default:
-			err = errors.New("@PastOrPresent wrong type")
-		}
-	}).ElseDo(func() {
-		err = errors.New("@PastOrPresent nil value")
-	})
-
-	return err
-}
-
-// present
-// @Present
-func present(v any) error {
-	notPresentErr := errors.New("@Present not present time")
-	var err error = nil
-	typex.Opt(v).IfPresent(func(v any) {
-		now := time.Now()
-
-		switch v.(type) {
-		case time.Time:
-			t := v.(time.Time)
-			if !equal(t, now) {
-				err = notPresentErr
-			}
-		case *time.Time:
-			t := v.(*time.Time)
-			if !equal(*t, now) {
-				err = notPresentErr
-			}
-		default:
-			err = errors.New("@Present wrong type")
-		}
-	}).ElseDo(func() {
-		err = errors.New("@Present nil value")
-	})
-
-	return err
-}
-
-// past
-// @Past
-func past(v any) error {
-	notPastErr := errors.New("@Past not past time")
-	var err error = nil
-	typex.Opt(v).IfPresent(func(v any) {
-		now := time.Now()
-
-		switch v.(type) {
-		case time.Time:
-			t := v.(time.Time)
-			if equal(t, now) || t.After(now) {
-				err = notPastErr
-			}
-		case *time.Time:
-			t := v.(*time.Time)
-			if equal(*t, now) || t.After(now) {
-				err = notPastErr
-			}
-		default:
-			err = errors.New("@Past wrong type")
-		}
-	}).ElseDo(func() {
-		err = errors.New("@Past nil value")
-	})
+// checkSign
+// NaN has no sign and is rejected
+func checkSign(v any, annotation string, accepts func(sign int) bool, detail string) error {
+	n, isNil, err := numberOf(annotation, v)
+	switch {
+	case err != nil:
+		return err
+	case isNil:
+		return nilErr(annotation, v)
+	}
 
-	return err
-}
+	if sign, ordered := n.sign(); !ordered || !accepts(sign) {
+		return jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonRange, v, detail)
+	}
 
-func equal(t1 time.Time, t2 time.Time) bool {
-	return t1.Unix()-t2.Unix() == 0
+	return nil
 }
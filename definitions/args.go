@@ -0,0 +1,73 @@
+package definitions
+
+import (
+	"errors"
+	"strings"
+)
+
+// parseAnnotation
+// "Length(1, 50)" -> "Length", ["1", "50"]
+// an argument can be single-quoted to contain commas: "DateTime('Mon, 02 Jan 2006')"
+func parseAnnotation(v string) (string, []string, error) {
+	v = strings.TrimSpace(v)
+	open := strings.IndexByte(v, '(')
+	if open < 0 {
+		return v, nil, nil
+	}
+
+	if !strings.HasSuffix(v, ")") {
+		return "", nil, errors.New("unclosed annotation arguments")
+	}
+
+	name := strings.TrimSpace(v[:open])
+	args, err := splitArgs(v[open+1 : len(v)-1])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, args, nil
+}
+
+func splitArgs(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	args := make([]string, 0, 2)
+	current := strings.Builder{}
+	quoted := false
+	wasQuoted := false
+
+	for _, r := range s {
+		switch {
+		case r == '\'':
+			if !wasQuoted && strings.TrimSpace(current.String()) == "" {
+				current.Reset()
+			}
+			quoted = !quoted
+			wasQuoted = true
+		case r == ' ' && !quoted && wasQuoted:
+			// spaces around a quoted argument
+		case r == ',' && !quoted:
+			args = append(args, trimArg(current.String(), wasQuoted))
+			current.Reset()
+			wasQuoted = false
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return nil, errors.New("unclosed quote in annotation arguments")
+	}
+
+	return append(args, trimArg(current.String(), wasQuoted)), nil
+}
+
+func trimArg(arg string, quoted bool) string {
+	if quoted {
+		return arg
+	}
+
+	return strings.TrimSpace(arg)
+}
@@ -0,0 +1,164 @@
+package definitions
+
+import (
+	"errors"
+	"github.com/aivyss/jsonx/civil"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+type boundKind int
+
+const (
+	boundNumber boundKind = iota
+	boundDuration
+	boundDate
+	boundTimeOfDay
+)
+
+// bound
+// an argument of @Min, @Max and @Between: 10, 1h30m, 2024-01-01 or 09:00
+type bound struct {
+	kind      boundKind
+	number    *big.Rat
+	duration  time.Duration
+	date      civil.Date
+	timeOfDay civil.TimeOfDay
+}
+
+func parseBound(arg string) (bound, error) {
+	if n, err := parseNumberArg(arg); err == nil {
+		return bound{kind: boundNumber, number: n}, nil
+	}
+	if d, err := time.ParseDuration(arg); err == nil {
+		return bound{kind: boundDuration, duration: d}, nil
+	}
+	if d, err := civil.ParseDate(arg); err == nil {
+		return bound{kind: boundDate, date: d}, nil
+	}
+	if t, err := civil.ParseTimeOfDay(arg); err == nil {
+		return bound{kind: boundTimeOfDay, timeOfDay: t}, nil
+	}
+
+	return bound{}, errors.New("argument is not a number, duration, date or time of day: " + arg)
+}
+
+// compare
+// -1, 0 or +1 when v is less than, equal to or greater than the bound.
+// values of other types are a wrong_type error and NaN, which is not ordered, a range error.
+func (b bound) compare(annotation string, v any) (c int, isNil bool, err error) {
+	var ok bool
+	switch b.kind {
+	case boundDuration:
+		var d time.Duration
+		d, isNil, ok = durationOf(v)
+		c = compareInt64(int64(d), int64(b.duration))
+	case boundDate:
+		var d civil.Date
+		d, isNil, ok = derefOf[civil.Date](v)
+		c = d.Compare(b.date)
+	case boundTimeOfDay:
+		var t civil.TimeOfDay
+		t, isNil, ok = derefOf[civil.TimeOfDay](v)
+		c = t.Compare(b.timeOfDay)
+	default:
+		n, isNil, err := numberOf(annotation, v)
+		if err != nil || isNil {
+			return 0, isNil, err
+		}
+		c, ordered := n.cmp(b.number)
+		if !ordered {
+			return 0, false, jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonRange, v, n.String()+" is not ordered")
+		}
+		return c, false, nil
+	}
+
+	if !ok {
+		return 0, false, wrongTypeErr(annotation, v)
+	}
+
+	return c, isNil, nil
+}
+
+// value
+// the bound as a value of its kind
+func (b bound) value() any {
+	switch b.kind {
+	case boundDuration:
+		return b.duration
+	case boundDate:
+		return b.date
+	case boundTimeOfDay:
+		return b.timeOfDay
+	default:
+		return b.number
+	}
+}
+
+// supports
+// the types the bound can be compared with
+func (b bound) supports(t reflect.Type) bool {
+	switch b.kind {
+	case boundDuration:
+		return isDurationType(t)
+	case boundDate:
+		return isDateType(t)
+	case boundTimeOfDay:
+		return isTimeOfDayType(t)
+	default:
+		return isNumberType(t)
+	}
+}
+
+// supportsBound
+// @Min(1) supports numbers, @Min(1m) durations, @Min(2024-01-01) dates and @Min(09:00) times of day
+func supportsBound(args []string) func(t reflect.Type) bool {
+	b, err := parseBound(args[0])
+	if err != nil {
+		return isNumberType
+	}
+
+	return b.supports
+}
+
+// derefOf
+// the T of a T or a non-nil *T
+func derefOf[T any](v any) (value T, isNil bool, ok bool) {
+	switch value := v.(type) {
+	case T:
+		return value, false, true
+	case *T:
+		if value == nil {
+			var zero T
+			return zero, true, true
+		}
+		return *value, false, true
+	default:
+		var zero T
+		return zero, false, false
+	}
+}
+
+// durationOf
+// time.Duration and civil.Duration values and pointers
+func durationOf(v any) (time.Duration, bool, bool) {
+	if d, isNil, ok := derefOf[time.Duration](v); ok {
+		return d, isNil, ok
+	}
+	d, isNil, ok := derefOf[civil.Duration](v)
+
+	return time.Duration(d), isNil, ok
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
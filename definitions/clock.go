@@ -0,0 +1,33 @@
+package definitions
+
+import "time"
+
+// Clock
+// the source of the current time of @Future, @Past, @Present, @FutureOrPresent, @PastOrPresent, @PastWithin and @FutureAfter
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc
+// adapts a function such as time.Now to Clock
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+var clock Clock = ClockFunc(time.Now)
+
+// SetClock
+// replaces the clock of the temporal annotations, nil restores time.Now
+func SetClock(c Clock) {
+	if c == nil {
+		c = ClockFunc(time.Now)
+	}
+
+	clock = c
+}
+
+func now() time.Time {
+	return clock.Now()
+}
@@ -0,0 +1,122 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// iso8601Date
+// the calendar date of ISO 8601, the same as time.DateOnly
+const iso8601Date = "2006-01-02"
+
+// namedLayouts
+// standards accepted by name wherever a layout is expected, e.g. @DateTime(RFC1123)
+var namedLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+	"ISO8601Date": iso8601Date,
+}
+
+// knownTimezones
+// names time.LoadLocation has found, it reads the zoneinfo on every call
+var knownTimezones sync.Map
+
+// layoutOf
+// the Go layout of a layout argument: a name of namedLayouts or a layout such as '02/01/2006 15:04'
+func layoutOf(arg string) (string, error) {
+	if layout, ok := namedLayouts[arg]; ok {
+		return layout, nil
+	}
+
+	// a layout without reference time elements formats any time to itself
+	if arg == "" || time.Date(1999, time.December, 31, 23, 59, 58, 0, time.UTC).Format(arg) == arg {
+		return "", errors.New("wrong layout: " + arg)
+	}
+
+	return arg, nil
+}
+
+// bindDateTime
+// @DateTime(RFC3339) or @DateTime('2006-01-02 15:04')
+// a string that time.Parse accepts with the layout
+func bindDateTime(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) != 1 {
+		return nil, nil, errors.New("needs one layout argument")
+	}
+
+	layout, err := layoutOf(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		return eachString(v, "DateTime", func(s string) error {
+			return checkLayout(s, layout)
+		})
+	}, map[string]string{"layout": args[0]}, nil
+}
+
+// date
+// @Date
+// an ISO 8601 calendar date such as 2024-02-29
+func date(v any) error {
+	return eachString(v, "Date", func(s string) error {
+		return checkLayout(s, iso8601Date)
+	})
+}
+
+// rfc3339
+// @RFC3339
+// a date-time such as 2024-02-29T12:30:00Z or 2024-02-29T12:30:00.5+09:00
+func rfc3339(v any) error {
+	return eachString(v, "RFC3339", func(s string) error {
+		return checkLayout(s, time.RFC3339Nano)
+	})
+}
+
+// timezone
+// @Timezone
+// an IANA time zone name such as Asia/Seoul or UTC. "Local" is rejected.
+// the names are looked up in the zoneinfo of the system or of time/tzdata when imported.
+func timezone(v any) error {
+	return eachString(v, "Timezone", func(s string) error {
+		if _, ok := knownTimezones.Load(s); ok {
+			return nil
+		}
+		if s == "" || s == "Local" {
+			return fmt.Errorf("unknown time zone %q", s)
+		}
+		if _, err := time.LoadLocation(s); err != nil {
+			return fmt.Errorf("unknown time zone %q", s)
+		}
+		knownTimezones.Store(s, true)
+
+		return nil
+	})
+}
+
+func checkLayout(s, layout string) error {
+	if _, err := time.Parse(layout, s); err != nil {
+		var parseErr *time.ParseError
+		if errors.As(err, &parseErr) && parseErr.Message != "" {
+			return fmt.Errorf("%q does not match %q:%s", s, layout, parseErr.Message)
+		}
+		return fmt.Errorf("%q does not match %q", s, layout)
+	}
+
+	return nil
+}
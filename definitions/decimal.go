@@ -0,0 +1,232 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// eachDecimal
+// calls check with the exact value of a number, or of a numeric string such as "10.05" or "1e3".
+// floats are their shortest decimal representation, so 0.1 has one fraction digit.
+// a nil pointer passes, combine with @Required.
+func eachDecimal(v any, annotation string, check func(r *big.Rat) error) error {
+	if isNumeric(v) {
+		n, isNil, err := numberOf(annotation, v)
+		switch {
+		case err != nil:
+			return err
+		case isNil:
+			return nil
+		case n.nan || n.inf != 0:
+			return jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonRange, v, n.String()+" is not finite")
+		}
+		return asAnnotationErr(annotation, v, check(n.rat))
+	}
+
+	return eachString(v, annotation, func(s string) error {
+		r, err := parseNumberArg(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a decimal number", s)
+		}
+
+		return check(r)
+	})
+}
+
+// hasScale
+// reports whether r has at most scale fraction digits, i.e. r * 10^scale is an integer
+func hasScale(r *big.Rat, scale int) bool {
+	return new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale))).IsInt()
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func digitsArg(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 0 {
+		return 0, errors.New("wrong number of digits: " + arg)
+	}
+
+	return n, nil
+}
+
+// bindDigits
+// @Digits(integer,fraction)
+// at most integer digits before and fraction digits after the decimal point, 123.45 fits @Digits(3,2)
+func bindDigits(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) != 2 {
+		return nil, nil, errors.New("needs two arguments (integer,fraction)")
+	}
+
+	integer, err := digitsArg(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	fraction, err := digitsArg(args[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	limit := new(big.Rat).SetInt(pow10(integer))
+
+	return func(v any) error {
+		return eachDecimal(v, "Digits", func(r *big.Rat) error {
+			if new(big.Rat).Abs(r).Cmp(limit) >= 0 {
+				return violation(jsonxErr.ReasonRange, "more than %d integer digits", integer)
+			}
+			if !hasScale(r, fraction) {
+				return violation(jsonxErr.ReasonRange, "more than %d fraction digits", fraction)
+			}
+
+			return nil
+		})
+	}, map[string]string{"integer": args[0], "fraction": args[1]}, nil
+}
+
+// bindScale
+// @Scale(2)
+// at most the given number of fraction digits, 10.50 and 10.5 fit @Scale(1)
+func bindScale(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) != 1 {
+		return nil, nil, errors.New("needs one argument")
+	}
+
+	scale, err := digitsArg(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		return eachDecimal(v, "Scale", func(r *big.Rat) error {
+			if !hasScale(r, scale) {
+				return violation(jsonxErr.ReasonRange, "more than %d fraction digits", scale)
+			}
+
+			return nil
+		})
+	}, map[string]string{"scale": args[0]}, nil
+}
+
+// bindMultipleOf
+// @MultipleOf(0.05)
+// an integer multiple of the positive step, checked exactly: 0.3 is a multiple of 0.1
+func bindMultipleOf(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) != 1 {
+		return nil, nil, errors.New("needs one argument")
+	}
+
+	step, err := parseNumberArg(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if step.Sign() <= 0 {
+		return nil, nil, errors.New("step is not positive: " + args[0])
+	}
+
+	return func(v any) error {
+		return eachDecimal(v, "MultipleOf", func(r *big.Rat) error {
+			if !new(big.Rat).Quo(r, step).IsInt() {
+				return violation(jsonxErr.ReasonRange, "not a multiple of %s", args[0])
+			}
+
+			return nil
+		})
+	}, map[string]string{"multipleOf": args[0]}, nil
+}
+
+// bindCurrency
+// @Currency or @Currency(USD)
+// without an argument, an ISO 4217 code such as "USD" or an amount with its code such as "10.05 USD".
+// with a code, an amount with at most the minor units of the currency, 10.005 fails @Currency(USD)
+// and "10.05 EUR" fails as a string of another currency.
+func bindCurrency(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) > 1 {
+		return nil, nil, errors.New("needs one or no argument")
+	}
+	if len(args) == 0 {
+		return func(v any) error {
+			return eachString(v, "Currency", func(s string) error {
+				return checkMoney(s, "")
+			})
+		}, map[string]string{"currency": "ISO 4217", "scale": ""}, nil
+	}
+
+	code := args[0]
+	minorUnits, ok := currencyMinorUnits[code]
+	if !ok {
+		return nil, nil, errors.New("unknown ISO 4217 currency: " + code)
+	}
+
+	return func(v any) error {
+		if isNumeric(v) {
+			return eachDecimal(v, "Currency", func(r *big.Rat) error {
+				return checkMinorUnits(r, code, minorUnits)
+			})
+		}
+
+		return eachString(v, "Currency", func(s string) error {
+			return checkMoney(s, code)
+		})
+	}, map[string]string{"currency": code, "scale": scaleParam(minorUnits)}, nil
+}
+
+// checkMoney
+// "USD" without a code to match, "10.05" with one, and "10.05 USD" in both cases
+func checkMoney(s, code string) error {
+	amount, moneyCode, hasCode := strings.Cut(s, " ")
+	switch {
+	case code == "" && !hasCode:
+		if _, ok := currencyMinorUnits[s]; !ok {
+			return fmt.Errorf("%q is not an ISO 4217 currency", s)
+		}
+		return nil
+	case code == "" || hasCode:
+		if _, ok := currencyMinorUnits[moneyCode]; !ok {
+			return fmt.Errorf("%q is not an ISO 4217 currency", moneyCode)
+		}
+		if code != "" && moneyCode != code {
+			return violation(jsonxErr.ReasonNotAllowed, "%s is not %s", moneyCode, code)
+		}
+	default:
+		amount, moneyCode = s, code
+	}
+
+	r, err := parseNumberArg(amount)
+	if err != nil {
+		return fmt.Errorf("%q is not a decimal number", amount)
+	}
+
+	return checkMinorUnits(r, moneyCode, currencyMinorUnits[moneyCode])
+}
+
+func checkMinorUnits(r *big.Rat, code string, minorUnits int) error {
+	if minorUnits != noMinorUnit && !hasScale(r, minorUnits) {
+		return violation(jsonxErr.ReasonRange, "more than %d fraction digits for %s", minorUnits, code)
+	}
+
+	return nil
+}
+
+func scaleParam(minorUnits int) string {
+	if minorUnits == noMinorUnit {
+		return ""
+	}
+
+	return strconv.Itoa(minorUnits)
+}
+
+// supportsCurrency
+// strings for @Currency, numbers and strings for @Currency(USD)
+func supportsCurrency(args []string) func(t reflect.Type) bool {
+	if len(args) == 0 {
+		return isStringOrContainer
+	}
+
+	return anyOf(isNumberType, isStringOrContainer)
+}
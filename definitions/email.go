@@ -0,0 +1,303 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"golang.org/x/net/idna"
+	"net/netip"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxLocalPartLength = 64
+	maxEmailLength     = 254
+
+	emailStrict    = "strict"
+	emailAllowIDN  = "allowIDN"
+	emailMaxLength = "maxLength="
+	emailAllow     = "allow="
+	emailDeny      = "deny="
+)
+
+// emailOptions
+// the arguments of @Email
+type emailOptions struct {
+	strict    bool
+	allowIDN  bool
+	maxLength int
+	allow     []string
+	deny      []string
+}
+
+// bindEmail
+// @Email or @Email(strict, allowIDN, maxLength=100, allow=example.com, deny=mailinator.com)
+// an RFC 5322 addr-spec: a dot-atom or quoted local part, a hostname or [address literal] domain.
+// strict accepts only dot-atom local parts and hostnames with at least two labels,
+// allowIDN accepts UTF-8 local parts and internationalized domains (RFC 6531),
+// maxLength limits the characters of the address (RFC 5321 limits always apply),
+// allow and deny match a domain and its subdomains and may be repeated. deny wins over allow.
+// a nil pointer passes, combine with @Required.
+func bindEmail(args []string) (AnnotationValidate, map[string]string, error) {
+	options := emailOptions{maxLength: maxEmailLength}
+	for _, arg := range args {
+		switch {
+		case arg == emailStrict:
+			options.strict = true
+		case arg == emailAllowIDN:
+			options.allowIDN = true
+		case strings.HasPrefix(arg, emailMaxLength):
+			maxLength, err := strconv.Atoi(strings.TrimPrefix(arg, emailMaxLength))
+			if err != nil || maxLength < 1 || maxLength > maxEmailLength {
+				return nil, nil, fmt.Errorf("wrong max length (1-%d): %s", maxEmailLength, arg)
+			}
+			options.maxLength = maxLength
+		case strings.HasPrefix(arg, emailAllow), strings.HasPrefix(arg, emailDeny):
+			key, value, _ := strings.Cut(arg, "=")
+			domain, err := asciiDomain(value, true)
+			if err == nil {
+				err = checkHostname(domain)
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("wrong %s domain %q: %w", key, value, err)
+			}
+
+			if key+"=" == emailAllow {
+				options.allow = append(options.allow, domain)
+			} else {
+				options.deny = append(options.deny, domain)
+			}
+		default:
+			return nil, nil, fmt.Errorf("wrong argument (%s, %s, %s<n>, %s<domain>, %s<domain>): %s",
+				emailStrict, emailAllowIDN, emailMaxLength, emailAllow, emailDeny, arg)
+		}
+	}
+
+	return func(v any) error {
+		return eachString(v, "Email", options.check)
+	}, map[string]string{
+		"maxLength": strconv.Itoa(options.maxLength),
+		"domains":   strings.Join(options.allow, ", "),
+	}, nil
+}
+
+func (o emailOptions) check(s string) error {
+	if s == "" {
+		return errors.New("empty value")
+	}
+	if !utf8.ValidString(s) {
+		return errors.New("invalid UTF-8")
+	}
+	if utf8.RuneCountInString(s) > o.maxLength {
+		return violation(jsonxErr.ReasonRange, "address is longer than %d characters", o.maxLength)
+	}
+
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return errors.New("missing '@'")
+	}
+
+	local, domain := s[:at], s[at+1:]
+	if err := o.checkLocalPart(local); err != nil {
+		return err
+	}
+
+	ascii, err := o.checkDomain(domain)
+	if err != nil {
+		return err
+	}
+	if len(local)+1+len(ascii) > maxEmailLength {
+		return fmt.Errorf("address is longer than %d octets", maxEmailLength)
+	}
+
+	return o.checkDomainLists(ascii)
+}
+
+// checkLocalPart
+// dot-atom or quoted-string, the obsolete forms and comments of RFC 5322 are not accepted
+func (o emailOptions) checkLocalPart(local string) error {
+	switch {
+	case local == "":
+		return errors.New("empty local part")
+	case len(local) > maxLocalPartLength:
+		return fmt.Errorf("local part is longer than %d octets", maxLocalPartLength)
+	}
+
+	if local[0] == '"' {
+		if o.strict {
+			return errors.New("quoted local part is not allowed")
+		}
+		return o.checkQuotedString(local)
+	}
+
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return fmt.Errorf("local part %q has an empty atom", local)
+		}
+
+		for _, r := range atom {
+			if err := o.checkNonASCII(r, "local part"); err != nil {
+				return err
+			}
+			if r < utf8.RuneSelf && !isAtext(byte(r)) {
+				return fmt.Errorf("invalid character %q in local part", r)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o emailOptions) checkQuotedString(local string) error {
+	if len(local) < 2 || local[len(local)-1] != '"' {
+		return errors.New("unterminated quoted local part")
+	}
+
+	escaped := false
+	for _, r := range local[1 : len(local)-1] {
+		if err := o.checkNonASCII(r, "local part"); err != nil {
+			return err
+		}
+
+		switch {
+		case r >= utf8.RuneSelf:
+		case escaped:
+			// quoted-pair: "\" (VCHAR / WSP)
+			if r != ' ' && r != '\t' && (r < '!' || r > '~') {
+				return fmt.Errorf("invalid escaped character %q in local part", r)
+			}
+		case r == '\\':
+			escaped = true
+			continue
+		case r == '"':
+			return errors.New("unescaped '\"' in quoted local part")
+		case r != ' ' && r != '\t' && (r < '!' || r > '~'):
+			return fmt.Errorf("invalid character %q in local part", r)
+		}
+		escaped = false
+	}
+
+	if escaped {
+		return errors.New("unterminated quoted local part")
+	}
+
+	return nil
+}
+
+// checkDomain
+// returns the domain in ASCII (A-labels), address literals are returned unchanged
+func (o emailOptions) checkDomain(domain string) (string, error) {
+	if domain == "" {
+		return "", errors.New("empty domain")
+	}
+
+	if domain[0] == '[' {
+		if o.strict {
+			return "", errors.New("address literal is not allowed")
+		}
+		return domain, checkAddressLiteral(domain)
+	}
+
+	for _, r := range domain {
+		if err := o.checkNonASCII(r, "domain"); err != nil {
+			return "", err
+		}
+	}
+
+	ascii, err := asciiDomain(domain, o.allowIDN)
+	if err != nil {
+		return "", err
+	}
+	if err := checkHostname(ascii); err != nil {
+		return "", fmt.Errorf("domain %w", err)
+	}
+	if o.strict && !strings.Contains(ascii, ".") {
+		return "", fmt.Errorf("domain %q has no top-level domain", domain)
+	}
+
+	return ascii, nil
+}
+
+// checkAddressLiteral
+// [192.0.2.1] or [IPv6:2001:db8::1]
+func checkAddressLiteral(domain string) error {
+	if domain[len(domain)-1] != ']' {
+		return fmt.Errorf("unterminated address literal %q", domain)
+	}
+
+	literal := domain[1 : len(domain)-1]
+	if v6, ok := strings.CutPrefix(literal, "IPv6:"); ok {
+		if addr, err := netip.ParseAddr(v6); err == nil && addr.Is6() && addr.Zone() == "" {
+			return nil
+		}
+	} else if addr, err := netip.ParseAddr(literal); err == nil && addr.Is4() {
+		return nil
+	}
+
+	return fmt.Errorf("invalid address literal %q", domain)
+}
+
+func (o emailOptions) checkDomainLists(domain string) error {
+	domain = strings.ToLower(domain)
+	for _, denied := range o.deny {
+		if isSubdomain(domain, denied) {
+			return violation(jsonxErr.ReasonNotAllowed, "domain %q is denied", domain)
+		}
+	}
+	if len(o.allow) == 0 {
+		return nil
+	}
+
+	for _, allowed := range o.allow {
+		if isSubdomain(domain, allowed) {
+			return nil
+		}
+	}
+
+	return violation(jsonxErr.ReasonNotAllowed, "domain %q is not allowed", domain)
+}
+
+func (o emailOptions) checkNonASCII(r rune, part string) error {
+	if r < utf8.RuneSelf || o.allowIDN {
+		return nil
+	}
+
+	return fmt.Errorf("non-ASCII character %q in %s requires allowIDN", r, part)
+}
+
+// isAtext
+// RFC 5322: ALPHA / DIGIT / "!" / "#" / "$" / "%" / "&" / "'" / "*" / "+" / "-" / "/" / "=" / "?" / "^" / "_" / "`" / "{" / "|" / "}" / "~"
+func isAtext(c byte) bool {
+	return isDigit(c) || (upper(c) >= 'A' && upper(c) <= 'Z') || strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) >= 0
+}
+
+func isSubdomain(domain, parent string) bool {
+	return domain == parent || strings.HasSuffix(domain, "."+parent)
+}
+
+// asciiDomain
+// converts the U-labels of an internationalized domain to lower case A-labels (xn--) with the IDNA lookup profile
+func asciiDomain(domain string, allowIDN bool) (string, error) {
+	if !allowIDN || isASCII(domain) {
+		return domain, nil
+	}
+
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalized domain %q: %w", domain, err)
+	}
+
+	return ascii, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
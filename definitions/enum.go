@@ -0,0 +1,57 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"strings"
+)
+
+// bindEnum
+// @Enum(draft,published,'on hold') or @Enum(1,2,3)
+// strings are compared as they are, numbers by value
+func bindEnum(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil, errors.New("needs at least one value")
+	}
+
+	return func(v any) error {
+		switch value := v.(type) {
+		case string:
+			return enumString(args, v, value)
+		case *string:
+			if value == nil {
+				return nilErr("Enum", v)
+			}
+			return enumString(args, v, *value)
+		}
+
+		n, isNil, err := numberOf("Enum", v)
+		switch {
+		case err != nil:
+			return err
+		case isNil:
+			return nilErr("Enum", v)
+		}
+
+		for _, arg := range args {
+			if allowed, err := parseNumberArg(arg); err == nil {
+				if c, ordered := n.cmp(allowed); ordered && c == 0 {
+					return nil
+				}
+			}
+		}
+
+		return jsonxErr.NewAnnotationErr("Enum", jsonxErr.ReasonNotAllowed, v, fmt.Sprintf("%s is not allowed", n))
+	}, map[string]string{"values": strings.Join(args, ", ")}, nil
+}
+
+func enumString(args []string, v any, s string) error {
+	for _, arg := range args {
+		if arg == s {
+			return nil
+		}
+	}
+
+	return jsonxErr.NewAnnotationErr("Enum", jsonxErr.ReasonNotAllowed, v, fmt.Sprintf("%q is not allowed", s))
+}
@@ -0,0 +1,52 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+)
+
+// reasonError
+// a violation other than ReasonFormat found by a check function of eachString or eachFile
+type reasonError struct {
+	reason jsonxErr.AnnotationReason
+	detail string
+}
+
+func (e *reasonError) Error() string {
+	return e.detail
+}
+
+// violation
+// the error of a check function, typed with the annotation and the value by asAnnotationErr
+func violation(reason jsonxErr.AnnotationReason, format string, args ...any) error {
+	return &reasonError{reason: reason, detail: fmt.Sprintf(format, args...)}
+}
+
+// asAnnotationErr
+// the AnnotationError of a check function error, errors without reason are format errors
+func asAnnotationErr(annotation string, value any, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	reason := jsonxErr.ReasonFormat
+	var reasonErr *reasonError
+	if errors.As(err, &reasonErr) {
+		reason = reasonErr.reason
+	}
+
+	return jsonxErr.NewAnnotationErr(annotation, reason, value, err.Error())
+}
+
+func nilErr(annotation string, v any) error {
+	return jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonNil, v, "nil value")
+}
+
+func emptyErr(annotation string, v any) error {
+	return jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonEmpty, v, "empty value")
+}
+
+func wrongTypeErr(annotation string, v any) error {
+	return jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonWrongType, v, fmt.Sprintf("wrong type %T", v))
+}
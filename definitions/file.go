@@ -0,0 +1,175 @@
+package definitions
+
+import (
+	"errors"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// uploadedFile
+// implemented by jsonx.File
+type uploadedFile interface {
+	Filename() string
+	Size() int64
+	ContentType() string
+	DetectContentType() (string, error)
+}
+
+var (
+	uploadedFileType = reflect.TypeOf((*uploadedFile)(nil)).Elem()
+	byteUnits        = map[string]int64{
+		"B":   1,
+		"KB":  1 << 10,
+		"KIB": 1 << 10,
+		"MB":  1 << 20,
+		"MIB": 1 << 20,
+		"GB":  1 << 30,
+		"GIB": 1 << 30,
+	}
+)
+
+// parseBytes
+// "512", "10KB", "5MB", "1GB" (units are powers of 1024)
+func parseBytes(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+
+	number, unit := s, "B"
+	if i >= 0 {
+		number, unit = s[:i], strings.TrimSpace(s[i:])
+	}
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	multiplier, ok := byteUnits[unit]
+	if err != nil || !ok {
+		return 0, errors.New("wrong byte size: " + s)
+	}
+
+	return n * multiplier, nil
+}
+
+// bindMaxBytes
+// @MaxBytes(5MB)
+// size of uploaded files or length of strings and []byte in bytes.
+// a nil file passes, combine with @Required.
+func bindMaxBytes(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) != 1 {
+		return nil, nil, errors.New("needs one argument")
+	}
+
+	maxBytes, err := parseBytes(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		return eachFile(v, "MaxBytes", func(size int64, _ uploadedFile) error {
+			if size > maxBytes {
+				return violation(jsonxErr.ReasonRange, "%d bytes is larger than %s", size, args[0])
+			}
+
+			return nil
+		})
+	}, map[string]string{"max": args[0]}, nil
+}
+
+// bindContentType
+// @ContentType(image/png,image/jpeg) or @ContentType(image/*)
+// both the media type declared for uploaded files and the one sniffed from their content must be allowed,
+// a .png upload of a script fails @ContentType(image/png).
+// a nil file passes, combine with @Required.
+func bindContentType(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil, errors.New("needs at least one media type")
+	}
+
+	allowed := func(contentType string) bool {
+		for _, pattern := range args {
+			if matchMediaType(pattern, contentType) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return func(v any) error {
+		return eachFile(v, "ContentType", func(_ int64, file uploadedFile) error {
+			if contentType := file.ContentType(); !allowed(contentType) {
+				return violation(jsonxErr.ReasonNotAllowed, "%q is not allowed", contentType)
+			}
+
+			detected, err := file.DetectContentType()
+			if err != nil {
+				return err
+			}
+			if detected, _, _ = mime.ParseMediaType(detected); !allowed(detected) {
+				return violation(jsonxErr.ReasonNotAllowed, "content detected as %q is not allowed", detected)
+			}
+
+			return nil
+		})
+	}, map[string]string{"types": strings.Join(args, ", ")}, nil
+}
+
+func matchMediaType(pattern, mediaType string) bool {
+	pattern = strings.ToLower(pattern)
+	mediaType = strings.ToLower(mediaType)
+
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+
+	return pattern == mediaType
+}
+
+// eachFile
+// calls check with the size of every file in v and the file itself, nil for strings and []byte
+func eachFile(v any, annotation string, check func(size int64, file uploadedFile) error) error {
+	switch value := v.(type) {
+	case string:
+		return asAnnotationErr(annotation, v, check(int64(len(value)), nil))
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return asAnnotationErr(annotation, v, check(int64(len(*value)), nil))
+	case []byte:
+		return asAnnotationErr(annotation, v, check(int64(len(value)), nil))
+	}
+
+	valueOf := reflect.ValueOf(v)
+	if valueOf.Kind() == reflect.Slice {
+		for i := 0; i < valueOf.Len(); i++ {
+			if err := eachFile(valueOf.Index(i).Interface(), annotation, check); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if valueOf.Kind() == reflect.Pointer && valueOf.IsNil() {
+		return nil
+	}
+
+	file, ok := v.(uploadedFile)
+	if !ok {
+		return wrongTypeErr(annotation, v)
+	}
+
+	return asAnnotationErr(annotation, v, check(file.Size(), file))
+}
+
+func isFileType(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	return t.Implements(uploadedFileType)
+}
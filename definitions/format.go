@@ -0,0 +1,308 @@
+package definitions
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	uuidLength   = 36
+	ulidLength   = 26
+	crockford32  = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base64Std    = "std"
+	base64URL    = "url"
+	semverFields = 3
+)
+
+// eachString
+// calls check with the string, or with every element of a slice, array or map of strings.
+// a nil *string passes, combine with @Required. an empty string failing check is reported as ReasonEmpty.
+func eachString(v any, annotation string, check func(s string) error) error {
+	switch value := v.(type) {
+	case string:
+		return checkString(annotation, value, value, check)
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return checkString(annotation, value, *value, check)
+	}
+
+	valueOf := reflect.ValueOf(v)
+	switch valueOf.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < valueOf.Len(); i++ {
+			elem := valueOf.Index(i).Interface()
+			if err := eachString(elem, annotation, check); err != nil {
+				return jsonxErr.NewElementErr("["+strconv.Itoa(i)+"]", elem, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		keys := valueOf.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, key := range keys {
+			elem := valueOf.MapIndex(key).Interface()
+			if err := eachString(elem, annotation, check); err != nil {
+				return jsonxErr.NewElementErr("."+fmt.Sprint(key), elem, err)
+			}
+		}
+		return nil
+	case reflect.Pointer:
+		if valueOf.IsNil() {
+			return nil
+		}
+	}
+
+	return wrongTypeErr(annotation, v)
+}
+
+func checkString(annotation string, value any, s string, check func(s string) error) error {
+	err := asAnnotationErr(annotation, value, check(s))
+
+	var annotationErr *jsonxErr.AnnotationError
+	if s == "" && errors.As(err, &annotationErr) && annotationErr.Reason == jsonxErr.ReasonFormat {
+		annotationErr.Reason = jsonxErr.ReasonEmpty
+	}
+
+	return err
+}
+
+// bindUUID
+// @UUID or @UUID(4) or @UUID(4,7)
+// 8-4-4-4-12 hex digits in either case. with versions, the RFC 9562 variant is required too.
+func bindUUID(args []string) (AnnotationValidate, map[string]string, error) {
+	versions := map[byte]bool{}
+	for _, arg := range args {
+		version, err := strconv.Atoi(arg)
+		if err != nil || version < 1 || version > 8 {
+			return nil, nil, errors.New("wrong UUID version: " + arg)
+		}
+		versions["0123456789"[version]] = true
+	}
+
+	return func(v any) error {
+		return eachString(v, "UUID", func(s string) error {
+			return checkUUID(s, versions)
+		})
+	}, map[string]string{"versions": strings.Join(args, ", ")}, nil
+}
+
+func checkUUID(s string, versions map[byte]bool) error {
+	if len(s) != uuidLength {
+		return fmt.Errorf("wrong length %d, expected %d", len(s), uuidLength)
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return fmt.Errorf("expected '-' at position %d", i)
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return fmt.Errorf("invalid character %q at position %d", s[i], i)
+			}
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil
+	}
+	if !versions[s[14]] {
+		return violation(jsonxErr.ReasonNotAllowed, "version %c is not allowed", s[14])
+	}
+	if !strings.ContainsRune("89abAB", rune(s[19])) {
+		return errors.New("not an RFC 9562 variant")
+	}
+
+	return nil
+}
+
+// ulid
+// @ULID
+// 26 characters of Crockford's base32 in either case, the first one is at most 7
+func ulid(v any) error {
+	return eachString(v, "ULID", func(s string) error {
+		if len(s) != ulidLength {
+			return fmt.Errorf("wrong length %d, expected %d", len(s), ulidLength)
+		}
+
+		for i := 0; i < len(s); i++ {
+			if !strings.ContainsRune(crockford32, rune(upper(s[i]))) {
+				return fmt.Errorf("invalid character %q at position %d", s[i], i)
+			}
+		}
+		if s[0] > '7' {
+			return errors.New("timestamp overflow, the first character must be 0-7")
+		}
+
+		return nil
+	})
+}
+
+// hex
+// @Hex
+// one or more hex digits in either case, without prefix
+func hex(v any) error {
+	return eachString(v, "Hex", func(s string) error {
+		if s == "" {
+			return violation(jsonxErr.ReasonEmpty, "empty value")
+		}
+
+		for i := 0; i < len(s); i++ {
+			if !isHexDigit(s[i]) {
+				return fmt.Errorf("invalid character %q at position %d", s[i], i)
+			}
+		}
+
+		return nil
+	})
+}
+
+// bindBase64
+// @Base64 or @Base64(std): standard alphabet with padding
+// @Base64(url): URL-safe alphabet, padding is optional
+func bindBase64(args []string) (AnnotationValidate, map[string]string, error) {
+	encoding := base64Std
+	switch {
+	case len(args) > 1:
+		return nil, nil, errors.New("needs at most one argument")
+	case len(args) == 1:
+		encoding = args[0]
+	}
+	if encoding != base64Std && encoding != base64URL {
+		return nil, nil, errors.New("wrong encoding (std, url): " + encoding)
+	}
+
+	return func(v any) error {
+		return eachString(v, "Base64", func(s string) error {
+			if s == "" {
+				return violation(jsonxErr.ReasonEmpty, "empty value")
+			}
+
+			decoder := base64.StdEncoding.Strict()
+			if encoding == base64URL {
+				decoder = base64.URLEncoding.Strict()
+				if len(s)%4 != 0 {
+					decoder = base64.RawURLEncoding.Strict()
+				}
+			}
+
+			if _, err := decoder.DecodeString(s); err != nil {
+				var corrupt base64.CorruptInputError
+				if errors.As(err, &corrupt) {
+					return fmt.Errorf("invalid %s base64 at position %d", encoding, int64(corrupt))
+				}
+				return fmt.Errorf("invalid %s base64", encoding)
+			}
+
+			return nil
+		})
+	}, map[string]string{"encoding": encoding}, nil
+}
+
+// semver
+// @Semver
+// Semantic Versioning 2.0.0: 1.2.3, 1.0.0-rc.1+build.5, without "v" prefix
+func semver(v any) error {
+	return eachString(v, "Semver", func(s string) error {
+		version, build, hasBuild := strings.Cut(s, "+")
+		version, preRelease, hasPreRelease := strings.Cut(version, "-")
+
+		core := strings.Split(version, ".")
+		if len(core) != semverFields {
+			return errors.New("expected MAJOR.MINOR.PATCH")
+		}
+		for i, part := range core {
+			if err := checkNumericIdentifier(part); err != nil {
+				return fmt.Errorf("%s version %w", [...]string{"major", "minor", "patch"}[i], err)
+			}
+		}
+
+		if hasPreRelease {
+			for _, identifier := range strings.Split(preRelease, ".") {
+				if err := checkIdentifier(identifier); err != nil {
+					return fmt.Errorf("pre-release %w", err)
+				}
+				if isDigits(identifier) {
+					if err := checkNumericIdentifier(identifier); err != nil {
+						return fmt.Errorf("pre-release %w", err)
+					}
+				}
+			}
+		}
+
+		if hasBuild {
+			for _, identifier := range strings.Split(build, ".") {
+				if err := checkIdentifier(identifier); err != nil {
+					return fmt.Errorf("build metadata %w", err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func checkNumericIdentifier(s string) error {
+	switch {
+	case s == "":
+		return errors.New("is empty")
+	case !isDigits(s):
+		return fmt.Errorf("%q is not a number", s)
+	case len(s) > 1 && s[0] == '0':
+		return fmt.Errorf("%q has a leading zero", s)
+	}
+
+	return nil
+}
+
+func checkIdentifier(s string) error {
+	if s == "" {
+		return errors.New("has an empty identifier")
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(isDigit(c) || c == '-' || (upper(c) >= 'A' && upper(c) <= 'Z')) {
+			return fmt.Errorf("identifier %q has invalid character %q", s, c)
+		}
+	}
+
+	return nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (upper(c) >= 'A' && upper(c) <= 'F')
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+
+	return c
+}
@@ -0,0 +1,342 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxHostnameLength = 253
+	maxLabelLength    = 63
+	maxPort           = 65535
+
+	ipPublic     = "public"
+	ipNoPrivate  = "noPrivate"
+	ipNoLoopback = "noLoopback"
+)
+
+// nat64Prefix
+// the well-known NAT64 prefix (RFC 6052), its last 32 bits are an IPv4 address
+var nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+
+// nonPublicPrefixes
+// special purpose ranges (RFC 6890) that netip.Addr has no predicate for
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	nat64Prefix,
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// bindURL
+// @URL or @URL(https) or @URL(http,https)
+// an absolute URL with a host, the scheme is checked against the arguments when given
+func bindURL(args []string) (AnnotationValidate, map[string]string, error) {
+	schemes := make([]string, 0, len(args))
+	for _, arg := range args {
+		if !isScheme(arg) {
+			return nil, nil, errors.New("wrong scheme: " + arg)
+		}
+		schemes = append(schemes, strings.ToLower(arg))
+	}
+
+	return func(v any) error {
+		return eachString(v, "URL", func(s string) error {
+			u, err := parseURI(s)
+			if err != nil {
+				return err
+			}
+
+			if len(schemes) > 0 && !contains(schemes, strings.ToLower(u.Scheme)) {
+				return violation(jsonxErr.ReasonNotAllowed, "scheme %q is not allowed", u.Scheme)
+			}
+			if u.Opaque != "" || u.Host == "" {
+				return errors.New("missing host")
+			}
+			return checkURLHost(u)
+		})
+	}, map[string]string{"schemes": strings.Join(schemes, ", ")}, nil
+}
+
+// uri
+// @URI
+// an absolute URI (RFC 3986) such as https://example.com, mailto:a@example.com or urn:isbn:0451450523
+func uri(v any) error {
+	return eachString(v, "URI", func(s string) error {
+		_, err := parseURI(s)
+		return err
+	})
+}
+
+func parseURI(s string) (*url.URL, error) {
+	if s == "" {
+		return nil, errors.New("empty value")
+	}
+	if i := strings.IndexFunc(s, func(r rune) bool { return r <= ' ' || r == 0x7f }); i >= 0 {
+		return nil, fmt.Errorf("invalid character %q at position %d", s[i], i)
+	}
+
+	scheme, _, found := strings.Cut(s, ":")
+	if !found || !isScheme(scheme) {
+		return nil, errors.New("missing scheme")
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func checkURLHost(u *url.URL) error {
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("missing host")
+	}
+	if port := u.Port(); port != "" {
+		if err := checkPort(port); err != nil {
+			return err
+		}
+	} else if strings.HasSuffix(u.Host, ":") {
+		return errors.New("empty port")
+	}
+
+	if strings.HasPrefix(u.Host, "[") {
+		if _, err := netip.ParseAddr(host); err != nil {
+			return fmt.Errorf("invalid IPv6 host %q", host)
+		}
+		return nil
+	}
+	if _, err := netip.ParseAddr(host); err == nil {
+		return nil
+	}
+
+	return checkHostname(host)
+}
+
+// hostname
+// @Hostname
+// RFC 1123: dot-separated labels of letters, digits and hyphens
+func hostname(v any) error {
+	return eachString(v, "Hostname", checkHostname)
+}
+
+func checkHostname(s string) error {
+	switch {
+	case s == "":
+		return violation(jsonxErr.ReasonEmpty, "empty host")
+	case len(s) > maxHostnameLength:
+		return fmt.Errorf("host is longer than %d characters", maxHostnameLength)
+	}
+
+	labels := strings.Split(s, ".")
+	for _, label := range labels {
+		switch {
+		case label == "":
+			return fmt.Errorf("empty label in %q", s)
+		case len(label) > maxLabelLength:
+			return fmt.Errorf("label %q is longer than %d characters", label, maxLabelLength)
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return fmt.Errorf("label %q starts or ends with a hyphen", label)
+		}
+
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(isDigit(c) || c == '-' || (upper(c) >= 'A' && upper(c) <= 'Z')) {
+				return fmt.Errorf("invalid character %q in label %q", c, label)
+			}
+		}
+	}
+
+	if isDigits(labels[len(labels)-1]) {
+		return fmt.Errorf("top-level label %q is numeric", labels[len(labels)-1])
+	}
+
+	return nil
+}
+
+// bindIP
+// @IP, @IPv4 and @IPv6 with optional range restrictions:
+// public rejects every address that is not global unicast (private, loopback, link-local, reserved, ...),
+// noPrivate rejects private and link-local ranges, such as the metadata address 169.254.169.254 and fe80::1,
+// and noLoopback rejects loopback and unspecified addresses (0.0.0.0 and ::), which reach the local host.
+// IPv4-mapped IPv6 addresses are checked as IPv4, NAT64 addresses (64:ff9b::/96) as their embedded IPv4 as well.
+func bindIP(name string, accepts func(addr netip.Addr) bool) func(args []string) (AnnotationValidate, map[string]string, error) {
+	return func(args []string) (AnnotationValidate, map[string]string, error) {
+		restrictions := map[string]bool{}
+		for _, arg := range args {
+			switch arg {
+			case ipPublic, ipNoPrivate, ipNoLoopback:
+				restrictions[arg] = true
+			default:
+				return nil, nil, fmt.Errorf("wrong argument (%s, %s, %s): %s", ipPublic, ipNoPrivate, ipNoLoopback, arg)
+			}
+		}
+
+		return func(v any) error {
+			return eachString(v, name, func(s string) error {
+				addr, err := netip.ParseAddr(s)
+				if err != nil {
+					return fmt.Errorf("invalid address %q", s)
+				}
+				if !accepts(addr) {
+					return fmt.Errorf("wrong address family %q", s)
+				}
+
+				return checkAddrRange(addr.Unmap(), restrictions)
+			})
+		}, map[string]string{"restrictions": strings.Join(args, ", ")}, nil
+	}
+}
+
+func checkAddrRange(addr netip.Addr, restrictions map[string]bool) error {
+	if nat64Prefix.Contains(addr) && !restrictions[ipPublic] {
+		// 64:ff9b::7f00:1 reaches 127.0.0.1 through a NAT64 gateway
+		b := addr.As16()
+		if err := checkAddrRange(netip.AddrFrom4([4]byte(b[12:])), restrictions); err != nil {
+			return fmt.Errorf("%w, embedded in %s", err, addr)
+		}
+	}
+	if (restrictions[ipNoLoopback] || restrictions[ipPublic]) && addr.IsLoopback() {
+		return violation(jsonxErr.ReasonNotAllowed, "loopback address %s is not allowed", addr)
+	}
+	if (restrictions[ipNoPrivate] || restrictions[ipPublic]) && addr.IsPrivate() {
+		return violation(jsonxErr.ReasonNotAllowed, "private address %s is not allowed", addr)
+	}
+	if restrictions[ipNoLoopback] && addr.IsUnspecified() {
+		return violation(jsonxErr.ReasonNotAllowed, "unspecified address %s is not allowed", addr)
+	}
+	if restrictions[ipNoPrivate] && (addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast()) {
+		return violation(jsonxErr.ReasonNotAllowed, "link-local address %s is not allowed", addr)
+	}
+	if !restrictions[ipPublic] {
+		return nil
+	}
+
+	if !addr.IsGlobalUnicast() {
+		return violation(jsonxErr.ReasonNotAllowed, "non-public address %s is not allowed", addr)
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return violation(jsonxErr.ReasonNotAllowed, "reserved address %s is not allowed (%s)", addr, prefix)
+		}
+	}
+
+	return nil
+}
+
+// cidr
+// @CIDR
+// an IPv4 or IPv6 network such as 10.0.0.0/8, host bits must be zero
+func cidr(v any) error {
+	return eachString(v, "CIDR", func(s string) error {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return fmt.Errorf("invalid network %q", s)
+		}
+		if masked := prefix.Masked(); masked != prefix {
+			return fmt.Errorf("host bits are set, the network is %s", masked)
+		}
+
+		return nil
+	})
+}
+
+// mac
+// @MAC
+// EUI-48 or EUI-64 separated by colons or hyphens, or dotted in groups of four
+func mac(v any) error {
+	return eachString(v, "MAC", func(s string) error {
+		hw, err := net.ParseMAC(s)
+		if err != nil {
+			return fmt.Errorf("invalid address %q", s)
+		}
+		if len(hw) != 6 && len(hw) != 8 {
+			return fmt.Errorf("%d-byte address is neither EUI-48 nor EUI-64", len(hw))
+		}
+
+		return nil
+	})
+}
+
+// port
+// @Port
+// 1 to 65535, as integer or as decimal string without leading zeros
+func port(v any) error {
+	if isNumeric(v) {
+		n, isNil, err := numberOf("Port", v)
+		switch {
+		case err != nil:
+			return err
+		case isNil:
+			return nil
+		}
+		low, lowOrdered := n.cmp(big.NewRat(1, 1))
+		high, highOrdered := n.cmp(big.NewRat(maxPort, 1))
+		if !lowOrdered || !highOrdered || low < 0 || high > 0 {
+			return jsonxErr.NewAnnotationErr("Port", jsonxErr.ReasonRange, v, fmt.Sprintf("%s is out of range 1-%d", n, maxPort))
+		}
+		return nil
+	}
+
+	return eachString(v, "Port", checkPort)
+}
+
+func checkPort(s string) error {
+	if !isDigits(s) || (len(s) > 1 && s[0] == '0') {
+		return fmt.Errorf("invalid port %q", s)
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > maxPort {
+		return violation(jsonxErr.ReasonRange, "port %s is out of range 1-%d", s, maxPort)
+	}
+
+	return nil
+}
+
+// isScheme
+// RFC 3986: ALPHA *( ALPHA / DIGIT / "+" / "-" / "." )
+func isScheme(s string) bool {
+	if s == "" || upper(s[0]) < 'A' || upper(s[0]) > 'Z' {
+		return false
+	}
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if !(isDigit(c) || c == '+' || c == '-' || c == '.' || (upper(c) >= 'A' && upper(c) <= 'Z')) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+
+	return false
+}
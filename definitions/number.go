@@ -0,0 +1,188 @@
+package definitions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// number
+// an exact numeric value, finite values are rationals, infinities have a sign and NaN is unordered
+type number struct {
+	rat  *big.Rat
+	inf  int
+	nan  bool
+	text string
+}
+
+func (n number) String() string {
+	return n.text
+}
+
+// cmp
+// -1, 0 or +1 when n is less than, equal to or greater than r, false for NaN
+func (n number) cmp(r *big.Rat) (int, bool) {
+	switch {
+	case n.nan:
+		return 0, false
+	case n.inf != 0:
+		return n.inf, true
+	default:
+		return n.rat.Cmp(r), true
+	}
+}
+
+// sign
+// -1, 0 or +1, false for NaN
+func (n number) sign() (int, bool) {
+	return n.cmp(new(big.Rat))
+}
+
+// numberOf
+// reads integers, unsigned integers and floats of any named type by reflect.Kind,
+// json.Number, big.Int, big.Float and big.Rat, and their pointers, without losing precision.
+// floats are read as their shortest decimal representation, float32(0.1) is 0.1.
+// other types are a wrong_type error, json.Number text that is not a number is a format error
+// and an empty json.Number, the zero value of an absent field, is an empty error.
+func numberOf(annotation string, v any) (n number, isNil bool, err error) {
+	valueOf := reflect.ValueOf(v)
+	if !valueOf.IsValid() {
+		return number{}, true, nil
+	}
+	if !isNumeric(v) {
+		return number{}, false, wrongTypeErr(annotation, v)
+	}
+
+	if valueOf.Kind() == reflect.Pointer {
+		if valueOf.IsNil() {
+			return number{}, true, nil
+		}
+		valueOf = valueOf.Elem()
+	}
+
+	switch valueOf.Type() {
+	case jsonNumberType:
+		s := valueOf.String()
+		r, err := parseNumberArg(s)
+		switch {
+		case s == "":
+			return number{}, false, emptyErr(annotation, v)
+		case err != nil:
+			return number{}, false, jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonFormat, v, fmt.Sprintf("%q is not a number", s))
+		}
+		return number{rat: r, text: s}, false, nil
+	case bigIntType:
+		i := addrOf[big.Int](valueOf)
+		return number{rat: new(big.Rat).SetInt(i), text: i.String()}, false, nil
+	case bigFloatType:
+		f := addrOf[big.Float](valueOf)
+		if f.IsInf() {
+			return number{inf: f.Sign(), text: f.String()}, false, nil
+		}
+		if exp := f.MantExp(nil); exp > maxFloatExponent || exp < -maxFloatExponent {
+			// the binary exponent of 1e1000 is 3322
+			return number{}, false, jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonFormat, v, fmt.Sprintf("exponent is beyond %d", maxNumberExponent))
+		}
+		r, _ := f.Rat(nil)
+		return number{rat: r, text: f.Text('g', -1)}, false, nil
+	case bigRatType:
+		r := addrOf[big.Rat](valueOf)
+		return number{rat: r, text: r.RatString()}, false, nil
+	}
+
+	switch valueOf.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := valueOf.Float()
+		switch {
+		case math.IsNaN(f):
+			return number{nan: true, text: "NaN"}, false, nil
+		case math.IsInf(f, 0):
+			return number{inf: int(math.Copysign(1, f)), text: strconv.FormatFloat(f, 'g', -1, 64)}, false, nil
+		}
+		text := strconv.FormatFloat(f, 'g', -1, valueOf.Type().Bits())
+		r, _ := new(big.Rat).SetString(text)
+		return number{rat: r, text: text}, false, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := valueOf.Uint()
+		return number{rat: new(big.Rat).SetInt(new(big.Int).SetUint64(u)), text: strconv.FormatUint(u, 10)}, false, nil
+	default:
+		i := valueOf.Int()
+		return number{rat: new(big.Rat).SetInt64(i), text: strconv.FormatInt(i, 10)}, false, nil
+	}
+}
+
+// isNumeric
+// reports whether numberOf reads v, numbers and durations
+func isNumeric(v any) bool {
+	t := reflect.TypeOf(v)
+	return t != nil && (isNumberType(t) || isDurationType(t))
+}
+
+// addrOf
+// the *T of a T field, big numbers are used through pointers
+func addrOf[T any](valueOf reflect.Value) *T {
+	if valueOf.CanAddr() {
+		return valueOf.Addr().Interface().(*T)
+	}
+
+	value := valueOf.Interface().(T)
+	return &value
+}
+
+// parseNumberArg
+// a decimal argument such as 10, -0.5 or 1e3, kept exact
+func parseNumberArg(arg string) (*big.Rat, error) {
+	if len(arg) > maxNumberLen || exponentOf(arg) > maxNumberExponent {
+		return nil, fmt.Errorf("argument is longer than %d characters or its exponent is beyond %d", maxNumberLen, maxNumberExponent)
+	}
+	if _, err := strconv.ParseFloat(arg, 64); err != nil && !errors.Is(err, strconv.ErrRange) {
+		return nil, errors.New("argument is not a number: " + arg)
+	}
+
+	r, ok := new(big.Rat).SetString(arg)
+	if !ok || strings.ContainsAny(arg, "/xX_") {
+		return nil, errors.New("argument is not a number: " + arg)
+	}
+
+	return r, nil
+}
+
+const (
+	// maxNumberLen, maxNumberExponent and maxFloatExponent (in bits)
+	// bound the work of big.Rat, 1e999999 would compute a million digits for every value
+	maxNumberLen      = 1000
+	maxNumberExponent = 1000
+	maxFloatExponent  = maxNumberExponent * 10 / 3
+)
+
+// exponentOf
+// the magnitude of the exponent of a number such as 1e-5, 0 without an exponent
+func exponentOf(s string) int {
+	i := strings.LastIndexAny(s, "eE")
+	if i < 0 {
+		return 0
+	}
+
+	exponent, err := strconv.Atoi(strings.TrimPrefix(s[i+1:], "+"))
+	if errors.Is(err, strconv.ErrRange) {
+		return math.MaxInt
+	}
+	if exponent < 0 {
+		exponent = -exponent
+	}
+
+	return exponent
+}
+
+var (
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+	bigIntType     = reflect.TypeOf(big.Int{})
+	bigFloatType   = reflect.TypeOf(big.Float{})
+	bigRatType     = reflect.TypeOf(big.Rat{})
+)
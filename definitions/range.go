@@ -0,0 +1,112 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"math/big"
+	"reflect"
+	"unicode/utf8"
+)
+
+// bindMin
+// @Min(value), value is a number or a duration (1h30m), date (2024-01-01) or time of day (09:00) for those types
+func bindMin(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) != 1 {
+		return nil, nil, errors.New("needs one argument")
+	}
+
+	minValue, err := parseBound(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		c, isNil, err := minValue.compare("Min", v)
+		switch {
+		case err != nil:
+			return err
+		case isNil:
+			return nilErr("Min", v)
+		case c < 0:
+			return jsonxErr.NewAnnotationErr("Min", jsonxErr.ReasonRange, v, "less than "+args[0])
+		}
+
+		return nil
+	}, map[string]string{"min": args[0]}, nil
+}
+
+// bindMax
+// @Max(value), the same values as @Min
+func bindMax(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) != 1 {
+		return nil, nil, errors.New("needs one argument")
+	}
+
+	maxValue, err := parseBound(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		c, isNil, err := maxValue.compare("Max", v)
+		switch {
+		case err != nil:
+			return err
+		case isNil:
+			return nilErr("Max", v)
+		case c > 0:
+			return jsonxErr.NewAnnotationErr("Max", jsonxErr.ReasonRange, v, "greater than "+args[0])
+		}
+
+		return nil
+	}, map[string]string{"max": args[0]}, nil
+}
+
+// bindLength
+// @Length(min,max)
+// counts runes of strings and elements of slices, arrays and maps
+func bindLength(args []string) (AnnotationValidate, map[string]string, error) {
+	if len(args) != 2 {
+		return nil, nil, errors.New("needs two arguments (min,max)")
+	}
+
+	minValue, err := parseNumberArg(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	maxValue, err := parseNumberArg(args[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	if minValue.Cmp(maxValue) > 0 {
+		return nil, nil, errors.New("min is greater than max")
+	}
+
+	return func(v any) error {
+		valueOf := reflect.ValueOf(v)
+		if valueOf.Kind() == reflect.Pointer {
+			if valueOf.IsNil() {
+				return nilErr("Length", v)
+			}
+			valueOf = valueOf.Elem()
+		}
+
+		length := 0
+		switch valueOf.Kind() {
+		case reflect.String:
+			length = utf8.RuneCountInString(valueOf.String())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			length = valueOf.Len()
+		default:
+			return wrongTypeErr("Length", v)
+		}
+
+		if n := big.NewRat(int64(length), 1); n.Cmp(minValue) < 0 || n.Cmp(maxValue) > 0 {
+			detail := fmt.Sprintf("length %d is out of range [%s, %s]", length, args[0], args[1])
+			return jsonxErr.NewAnnotationErr("Length", jsonxErr.ReasonRange, v, detail)
+		}
+
+		return nil
+	}, map[string]string{"min": args[0], "max": args[1]}, nil
+}
@@ -0,0 +1,128 @@
+package definitions
+
+import (
+	"github.com/aivyss/jsonx/civil"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	dateType      = reflect.TypeOf(civil.Date{})
+	timeOfDayType = reflect.TypeOf(civil.TimeOfDay{})
+	durationTypes = map[reflect.Type]bool{
+		reflect.TypeOf(time.Duration(0)):  true,
+		reflect.TypeOf(civil.Duration(0)): true,
+	}
+)
+
+// elemOf
+// dereferences a single level of pointer
+func elemOf(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
+
+func isStringType(t reflect.Type) bool {
+	return elemOf(t) == reflect.TypeOf("")
+}
+
+// isStringOrContainer
+// string, *string and slices, arrays and maps of them
+func isStringOrContainer(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return isStringType(t.Elem())
+	default:
+		return isStringType(t)
+	}
+}
+
+// isNumberType
+// integers, unsigned integers and floats of any named type except durations, json.Number and math/big numbers
+func isNumberType(t reflect.Type) bool {
+	switch elem := elemOf(t); {
+	case elem == jsonNumberType, elem == bigIntType, elem == bigFloatType, elem == bigRatType:
+		return true
+	case isDurationType(elem):
+		return false
+	default:
+		return isIntegerKind(elem.Kind()) || elem.Kind() == reflect.Float32 || elem.Kind() == reflect.Float64
+	}
+}
+
+func isIntegerType(t reflect.Type) bool {
+	elem := elemOf(t)
+	return elem == bigIntType || (isNumberType(t) && isIntegerKind(elem.Kind()))
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isTimeType(t reflect.Type) bool {
+	return elemOf(t) == timeType
+}
+
+func isDateType(t reflect.Type) bool {
+	return elemOf(t) == dateType
+}
+
+func isTimeOfDayType(t reflect.Type) bool {
+	return elemOf(t) == timeOfDayType
+}
+
+// isDurationType
+// time.Duration and civil.Duration
+func isDurationType(t reflect.Type) bool {
+	return durationTypes[elemOf(t)]
+}
+
+func isNillableType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface, reflect.Chan, reflect.Func:
+		return true
+	default:
+		return false
+	}
+}
+
+func isSliceOf(elem func(t reflect.Type) bool) func(t reflect.Type) bool {
+	return func(t reflect.Type) bool {
+		return t.Kind() == reflect.Slice && elem(t.Elem())
+	}
+}
+
+func hasLength(t reflect.Type) bool {
+	switch elemOf(t).Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+func isByteSlice(t reflect.Type) bool {
+	return t == reflect.TypeOf([]byte(nil))
+}
+
+func anyOf(predicates ...func(t reflect.Type) bool) func(t reflect.Type) bool {
+	return func(t reflect.Type) bool {
+		for _, predicate := range predicates {
+			if predicate(t) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,216 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aivyss/jsonx/civil"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"reflect"
+	"time"
+)
+
+const (
+	granularitySecond = "second"
+	granularityMinute = "minute"
+	granularityHour   = "hour"
+	granularityDay    = "day"
+	granularityMonth  = "month"
+	granularityYear   = "year"
+)
+
+// presentTolerance
+// without tolerance and granularity the present is less than a second away in both directions,
+// like the generated TypeScript. times count nanoseconds, so at most 1s-1ns is less than 1s.
+const presentTolerance = time.Second - time.Nanosecond
+
+var granularities = map[string]bool{
+	granularitySecond: true,
+	granularityMinute: true,
+	granularityHour:   true,
+	granularityDay:    true,
+	granularityMonth:  true,
+	granularityYear:   true,
+}
+
+// temporal
+// the arguments of the temporal annotations, in any order:
+// a layout for strings (RFC3339, '2006-01-02'), and a tolerance (5s) or a granularity (second ... year).
+// without tolerance and granularity the present is less than a second away, see presentTolerance.
+type temporal struct {
+	layout      string
+	tolerance   time.Duration
+	granularity string
+}
+
+func parseTemporal(args []string) (temporal, map[string]string, error) {
+	p := temporal{}
+	params := map[string]string{"layout": "", "tolerance": "", "granularity": ""}
+
+	for _, arg := range args {
+		if granularities[arg] {
+			if p.granularity != "" {
+				return temporal{}, nil, errors.New("needs at most one granularity")
+			}
+			p.granularity, params["granularity"] = arg, arg
+			continue
+		}
+
+		if tolerance, err := time.ParseDuration(arg); err == nil {
+			if p.tolerance != 0 || tolerance <= 0 {
+				return temporal{}, nil, errors.New("needs at most one positive tolerance: " + arg)
+			}
+			p.tolerance, params["tolerance"] = tolerance, arg
+			continue
+		}
+
+		layout, err := layoutOf(arg)
+		if err != nil || p.layout != "" {
+			return temporal{}, nil, fmt.Errorf("wrong argument (layout, tolerance or granularity): %s", arg)
+		}
+		p.layout, params["layout"] = layout, arg
+	}
+
+	if p.tolerance != 0 && p.granularity != "" {
+		return temporal{}, nil, errors.New("tolerance and granularity exclude each other")
+	}
+	if p.tolerance == 0 && p.granularity == "" {
+		p.tolerance = presentTolerance
+	}
+
+	return p, params, nil
+}
+
+// compare
+// -1, 0 or +1 when t is before, in or after the present of now
+func (p temporal) compare(t, now time.Time) int {
+	if p.tolerance != 0 {
+		switch d := t.Sub(now); {
+		case d < -p.tolerance:
+			return -1
+		case d > p.tolerance:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	// calendar units are counted in the location of the clock
+	return truncate(t.In(now.Location()), p.granularity).Compare(truncate(now, p.granularity))
+}
+
+// compareDate
+// dates are compared with the date of the clock, by day at least and without tolerance
+func (p temporal) compareDate(d civil.Date, now time.Time) int {
+	granularity := p.granularity
+	switch granularity {
+	case "", granularitySecond, granularityMinute, granularityHour:
+		granularity = granularityDay
+	}
+
+	return temporal{granularity: granularity}.compare(d.In(now.Location()), now)
+}
+
+func truncate(t time.Time, granularity string) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+
+	switch granularity {
+	case granularityYear:
+		month, day, hour, minute, second = time.January, 1, 0, 0, 0
+	case granularityMonth:
+		day, hour, minute, second = 1, 0, 0, 0
+	case granularityDay:
+		hour, minute, second = 0, 0, 0
+	case granularityHour:
+		minute, second = 0, 0
+	case granularityMinute:
+		second = 0
+	}
+
+	return time.Date(year, month, day, hour, minute, second, 0, t.Location())
+}
+
+func isAfter(c int) bool     { return c > 0 }
+func isBefore(c int) bool    { return c < 0 }
+func isSame(c int) bool      { return c == 0 }
+func isNotBefore(c int) bool { return c >= 0 }
+func isNotAfter(c int) bool  { return c <= 0 }
+
+// bindTemporal
+// @Future, @Past, ... on time.Time and civil.Date, or on strings with a layout: @Future(RFC3339), @Present(5s), @PastOrPresent(day)
+// the current time comes from the clock, see SetClock
+func bindTemporal(name string, accepts func(c int) bool, detail string) func(args []string) (AnnotationValidate, map[string]string, error) {
+	return func(args []string) (AnnotationValidate, map[string]string, error) {
+		p, params, err := parseTemporal(args)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return func(v any) error {
+			if d, isNil, ok := derefOf[civil.Date](v); ok {
+				switch {
+				case isNil:
+					return nilErr(name, v)
+				case !accepts(p.compareDate(d, now())):
+					return jsonxErr.NewAnnotationErr(name, jsonxErr.ReasonRange, v, detail)
+				}
+				return nil
+			}
+
+			return eachTime(v, name, p.layout, func(t time.Time) error {
+				if !accepts(p.compare(t, now())) {
+					return violation(jsonxErr.ReasonRange, "%s", detail)
+				}
+
+				return nil
+			})
+		}, params, nil
+	}
+}
+
+// eachTime
+// checks a time.Time, a *time.Time or a string parsed with the layout, strings without layout are the wrong type
+func eachTime(v any, annotation, layout string, check func(t time.Time) error) error {
+	var t time.Time
+	switch value := v.(type) {
+	case string, *string:
+		if layout == "" {
+			return jsonxErr.NewAnnotationErr(annotation, jsonxErr.ReasonWrongType, v, "needs a layout argument for strings")
+		}
+		if value, ok := value.(*string); ok && value == nil {
+			return nilErr(annotation, v)
+		}
+
+		return eachString(v, annotation, func(s string) error {
+			t, err := time.Parse(layout, s)
+			if err != nil {
+				return checkLayout(s, layout)
+			}
+
+			return check(t)
+		})
+	case time.Time:
+		t = value
+	case *time.Time:
+		if value == nil {
+			return nilErr(annotation, v)
+		}
+		t = *value
+	case nil:
+		return nilErr(annotation, v)
+	default:
+		return wrongTypeErr(annotation, v)
+	}
+
+	return asAnnotationErr(annotation, v, check(t))
+}
+
+// supportsTemporal
+// time.Time, civil.Date and their pointers, strings only with a layout argument
+func supportsTemporal(args []string) func(t reflect.Type) bool {
+	if p, _, err := parseTemporal(args); err != nil || p.layout == "" {
+		return anyOf(isTimeType, isDateType)
+	}
+
+	return anyOf(isTimeType, isDateType, isStringType)
+}
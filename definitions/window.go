@@ -0,0 +1,273 @@
+package definitions
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aivyss/jsonx/civil"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"reflect"
+	"time"
+)
+
+// windowArgs
+// n bounds followed by an optional layout for strings
+func windowArgs(args []string, n int) ([]string, string, error) {
+	if len(args) != n && len(args) != n+1 {
+		return nil, "", fmt.Errorf("needs %d arguments and an optional layout", n)
+	}
+	if len(args) == n {
+		return args, "", nil
+	}
+
+	layout, err := layoutOf(args[n])
+	if err != nil {
+		return nil, "", err
+	}
+
+	return args[:n], layout, nil
+}
+
+// instantOf
+// a bound such as 2020-01-01 (UTC midnight) or 2020-01-01T09:00:00+09:00
+func instantOf(arg string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, iso8601Date} {
+		if t, err := time.Parse(layout, arg); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.New("wrong time (2006-01-02 or RFC3339): " + arg)
+}
+
+func positiveDuration(arg string) (time.Duration, error) {
+	d, err := time.ParseDuration(arg)
+	if err != nil || d <= 0 {
+		return 0, errors.New("wrong duration: " + arg)
+	}
+
+	return d, nil
+}
+
+// bindPastWithin
+// @PastWithin(24h) or @PastWithin(24h, RFC3339)
+// a time between the duration ago and now, both inclusive
+func bindPastWithin(args []string) (AnnotationValidate, map[string]string, error) {
+	bounds, layout, err := windowArgs(args, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err := positiveDuration(bounds[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		return eachTime(v, "PastWithin", layout, func(t time.Time) error {
+			now := now()
+			if t.After(now) || t.Before(now.Add(-d)) {
+				return violation(jsonxErr.ReasonRange, "not within the past %s", bounds[0])
+			}
+
+			return nil
+		})
+	}, windowParams(args, "duration"), nil
+}
+
+// bindFutureAfter
+// @FutureAfter(15m) or @FutureAfter(15m, RFC3339)
+// a time at least the duration after now
+func bindFutureAfter(args []string) (AnnotationValidate, map[string]string, error) {
+	bounds, layout, err := windowArgs(args, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err := positiveDuration(bounds[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		return eachTime(v, "FutureAfter", layout, func(t time.Time) error {
+			if t.Before(now().Add(d)) {
+				return violation(jsonxErr.ReasonRange, "not %s in the future", bounds[0])
+			}
+
+			return nil
+		})
+	}, windowParams(args, "duration"), nil
+}
+
+// bindAfter
+// @After(2020-01-01) or @After(2020-01-01T09:00:00+09:00, RFC3339)
+// a time strictly after the bound
+func bindAfter(args []string) (AnnotationValidate, map[string]string, error) {
+	bounds, layout, err := windowArgs(args, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err := instantOf(bounds[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		return eachTime(v, "After", layout, func(t time.Time) error {
+			if !t.After(after) {
+				return violation(jsonxErr.ReasonRange, "not after %s", bounds[0])
+			}
+
+			return nil
+		})
+	}, windowParams(args, "after"), nil
+}
+
+// bindBefore
+// @Before(2100-01-01) or @Before(2100-01-01, DateOnly)
+// a time strictly before the bound
+func bindBefore(args []string) (AnnotationValidate, map[string]string, error) {
+	bounds, layout, err := windowArgs(args, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	before, err := instantOf(bounds[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(v any) error {
+		return eachTime(v, "Before", layout, func(t time.Time) error {
+			if !t.Before(before) {
+				return violation(jsonxErr.ReasonRange, "not before %s", bounds[0])
+			}
+
+			return nil
+		})
+	}, windowParams(args, "before"), nil
+}
+
+// bindBetween
+// @Between(1900-01-01, 2100-01-01) or @Between(1900-01-01, 2100-01-01, DateOnly)
+// a time between the bounds, both inclusive. date bounds also accept civil.Date,
+// @Between(1m, 1h) checks durations and @Between(09:00, 18:00) times of day.
+func bindBetween(args []string) (AnnotationValidate, map[string]string, error) {
+	bounds, layout, err := windowArgs(args, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+	if from, to, ok := boundsOf(bounds, boundDuration, boundTimeOfDay); ok {
+		if layout != "" {
+			return nil, nil, errors.New("layout is only for times: " + args[2])
+		}
+		if c, _, _ := from.compare("Between", to.value()); c < 0 {
+			return nil, nil, fmt.Errorf("%s is before %s", bounds[1], bounds[0])
+		}
+
+		return func(v any) error {
+			return checkBetween(v, from, to, bounds)
+		}, windowParams(args, "from", "to"), nil
+	}
+
+	from, err := instantOf(bounds[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := instantOf(bounds[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	if to.Before(from) {
+		return nil, nil, fmt.Errorf("%s is before %s", bounds[1], bounds[0])
+	}
+	fromDate, toDate, dates := boundsOf(bounds, boundDate)
+
+	return func(v any) error {
+		if _, _, ok := derefOf[civil.Date](v); ok && dates {
+			return checkBetween(v, fromDate, toDate, bounds)
+		}
+
+		return eachTime(v, "Between", layout, func(t time.Time) error {
+			if t.Before(from) || t.After(to) {
+				return violation(jsonxErr.ReasonRange, "not between %s and %s", bounds[0], bounds[1])
+			}
+
+			return nil
+		})
+	}, windowParams(args, "from", "to"), nil
+}
+
+// boundsOf
+// the bounds of @Between when both are of one of the kinds
+func boundsOf(bounds []string, kinds ...boundKind) (bound, bound, bool) {
+	from, fromErr := parseBound(bounds[0])
+	to, toErr := parseBound(bounds[1])
+	if fromErr != nil || toErr != nil || from.kind != to.kind {
+		return bound{}, bound{}, false
+	}
+
+	for _, kind := range kinds {
+		if from.kind == kind {
+			return from, to, true
+		}
+	}
+
+	return bound{}, bound{}, false
+}
+
+func checkBetween(v any, from, to bound, bounds []string) error {
+	c, isNil, err := from.compare("Between", v)
+	switch {
+	case err != nil:
+		return err
+	case isNil:
+		return nilErr("Between", v)
+	}
+
+	if after, _, _ := to.compare("Between", v); c < 0 || after > 0 {
+		return jsonxErr.NewAnnotationErr("Between", jsonxErr.ReasonRange, v, fmt.Sprintf("not between %s and %s", bounds[0], bounds[1]))
+	}
+
+	return nil
+}
+
+// supportsBetween
+// durations and times of day for their bounds, otherwise the window types and civil.Date for date bounds
+func supportsBetween(args []string) func(t reflect.Type) bool {
+	bounds, _, err := windowArgs(args, 2)
+	if err != nil {
+		return isTimeType
+	}
+	if from, _, ok := boundsOf(bounds, boundDuration, boundTimeOfDay); ok {
+		return from.supports
+	}
+	if _, _, ok := boundsOf(bounds, boundDate); ok {
+		return anyOf(supportsWindow(2)(args), isDateType)
+	}
+
+	return supportsWindow(2)(args)
+}
+
+// windowParams
+// the bounds by name and the layout argument, e.g. {"from": "1900-01-01", "to": "2100-01-01", "layout": ""}
+func windowParams(args []string, names ...string) map[string]string {
+	params := map[string]string{"layout": ""}
+	for i, name := range names {
+		params[name] = args[i]
+	}
+	if len(args) > len(names) {
+		params["layout"] = args[len(names)]
+	}
+
+	return params
+}
+
+// supportsWindow
+// time.Time and *time.Time, strings only with a layout after the n bounds
+func supportsWindow(n int) func(args []string) func(t reflect.Type) bool {
+	return func(args []string) func(t reflect.Type) bool {
+		if _, layout, err := windowArgs(args, n); err != nil || layout == "" {
+			return isTimeType
+		}
+
+		return anyOf(isTimeType, isStringType)
+	}
+}
@@ -0,0 +1,175 @@
+package jsonx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/tag"
+	"github.com/aivyss/jsonx/validate"
+	"reflect"
+	"sort"
+)
+
+// TypeDescription
+// what Validate checks for a type: the tags of every field and the registered validators
+type TypeDescription struct {
+	Type   string             `json:"type"`
+	Fields []FieldDescription `json:"fields"`
+	// Validator is the type of the validator registered by RegisterValidator, it runs after the tags
+	Validator string `json:"validator,omitempty"`
+	// OrderedValidators run last, in this order
+	OrderedValidators []ValidatorDescription `json:"orderedValidators,omitempty"`
+}
+
+// FieldDescription
+// fields of embedded structs are promoted to the embedding struct like in JSON
+type FieldDescription struct {
+	Name string `json:"name"`
+	// JSONName is empty for `json:"-"` fields
+	JSONName    string                  `json:"jsonName,omitempty"`
+	Path        string                  `json:"path"`
+	Type        string                  `json:"type"`
+	Annotations []AnnotationDescription `json:"annotations,omitempty"`
+	Pattern     string                  `json:"pattern,omitempty"`
+	// FieldErrors maps annotation names and "pattern" to field error names
+	FieldErrors map[string]string `json:"fieldErrors,omitempty"`
+	// FieldError is used for failures without mapping
+	FieldError string `json:"fieldError,omitempty"`
+	Default    string `json:"default,omitempty"`
+	Doc        string `json:"doc,omitempty"`
+	// Recursive is set instead of Children when the struct type is already being described
+	Recursive bool               `json:"recursive,omitempty"`
+	Children  []FieldDescription `json:"children,omitempty"`
+}
+
+type AnnotationDescription struct {
+	Name   string            `json:"name"`
+	Args   []string          `json:"args,omitempty"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+type ValidatorDescription struct {
+	Type  string `json:"type"`
+	Order int    `json:"order"`
+}
+
+// Describe
+// the same field plan tagValidation uses, as data
+func Describe[T any]() (*TypeDescription, error) {
+	typeOf := reflect.TypeOf(new(T)).Elem()
+	if typeOf.Kind() != reflect.Struct {
+		return nil, errors.New("use only struct type")
+	}
+
+	fields, err := describeFields(typeOf, "", map[reflect.Type]bool{typeOf: true})
+	if err != nil {
+		return nil, err
+	}
+
+	description := &TypeDescription{
+		Type:   typeOf.String(),
+		Fields: fields,
+	}
+
+	if validator, ok := validatorMap[typeOf]; ok {
+		description.Validator = reflect.TypeOf(validator).String()
+	}
+
+	for _, v := range orderedValidatorMap.Get(typeOf) {
+		if ordered, ok := v.(validate.OrderedValidator[T]); ok {
+			description.OrderedValidators = append(description.OrderedValidators, ValidatorDescription{
+				Type:  reflect.TypeOf(ordered).String(),
+				Order: ordered.Order(),
+			})
+		}
+	}
+	sort.SliceStable(description.OrderedValidators, func(i, j int) bool {
+		return description.OrderedValidators[i].Order < description.OrderedValidators[j].Order
+	})
+
+	return description, nil
+}
+
+// describeFields
+// visiting holds the struct types on the current path, to stop at recursive types
+func describeFields(typeOf reflect.Type, path string, visiting map[reflect.Type]bool) ([]FieldDescription, error) {
+	fields := make([]FieldDescription, 0, typeOf.NumField())
+
+	for _, plan := range planOf(typeOf) {
+		if !plan.isVisible() {
+			continue
+		}
+
+		if plan.isPromoted() {
+			promoted, err := describeFields(plan.nested, path, visiting)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, promoted...)
+			continue
+		}
+
+		field, err := describeField(plan, path, visiting)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeOf, plan.field.Name, err)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func describeField(plan fieldPlan, path string, visiting map[reflect.Type]bool) (FieldDescription, error) {
+	field := FieldDescription{
+		Name:    plan.field.Name,
+		Path:    joinPath(path, plan.jsonName()),
+		Type:    plan.field.Type.String(),
+		Pattern: plan.pattern,
+		Default: plan.defaultValue,
+		Doc:     plan.doc,
+	}
+	if !plan.isJSONIgnored() {
+		field.JSONName = plan.jsonName()
+	}
+
+	for _, annoStr := range tag.SplitAnnotationTag(plan.annotations) {
+		annotation, err := definitions.ConvertToAnnotation(annoStr)
+		if err != nil {
+			return FieldDescription{}, fmt.Errorf("@%s: %w", annoStr, err)
+		}
+
+		field.Annotations = append(field.Annotations, AnnotationDescription{
+			Name:   annotation.Name(),
+			Args:   annotation.Args(),
+			Params: annotation.Params(),
+		})
+	}
+
+	if plan.fieldErr != "" {
+		mapping, fallback, err := parseFieldErrTag(plan.fieldErr)
+		if err != nil {
+			return FieldDescription{}, err
+		}
+		if len(mapping) > 0 {
+			field.FieldErrors = mapping
+		}
+		field.FieldError = fallback
+	}
+
+	if plan.nested != nil {
+		if visiting[plan.nested] {
+			field.Recursive = true
+			return field, nil
+		}
+
+		visiting[plan.nested] = true
+		children, err := describeFields(plan.nested, field.Path, visiting)
+		delete(visiting, plan.nested)
+		if err != nil {
+			return FieldDescription{}, err
+		}
+		field.Children = children
+	}
+
+	return field, nil
+}
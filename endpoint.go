@@ -3,12 +3,15 @@ package jsonx
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/aivyss/jsonx/definitions"
 	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/message"
 	"github.com/aivyss/jsonx/validate"
 	"github.com/aivyss/typex"
 	"reflect"
 	"sort"
+	"strings"
 )
 
 func RegisterValidator[T any](v validate.Validator[T]) {
@@ -25,7 +28,7 @@ func Unmarshal[V any](data []byte) (*V, error) {
 	v := new(V)
 	err := json.Unmarshal(data, v)
 	if err != nil {
-		return nil, errors.Join(errors.New("fail to unmarshal"), err)
+		return nil, errors.Join(jsonxErr.ErrUnmarshal, err)
 	}
 
 	if validationErr := Validate(*v); validationErr != nil {
@@ -43,6 +46,13 @@ func RegisterCustomAnnotation(annotationName string, validateFunc definitions.An
 	return definitions.RegisterCustomAnnotation(annotationName, validateFunc)
 }
 
+// SetClock
+// the current time of @Future, @Past, @Present, @FutureOrPresent, @PastOrPresent, @PastWithin and @FutureAfter, nil restores time.Now.
+// see jsonxtest.NewClock for deterministic tests
+func SetClock(clock definitions.Clock) {
+	definitions.SetClock(clock)
+}
+
 // Validate
 // don't input pointer type
 func Validate[T any](v T) error {
@@ -86,27 +96,96 @@ func Validate[T any](v T) error {
 	return nil
 }
 
-func RegisterFieldError(errorName, msg string) {
-	fieldErr := jsonxErr.NewFieldErr(errorName, msg)
-	fieldErrMap[fieldErr.Name()] = *fieldErr
+// RegisterFieldError
+// the returned sentinel matches every error exchanged for it with errors.Is
+func RegisterFieldError(errorName, msg string, opts ...jsonxErr.FieldErrOption) *jsonxErr.FieldError {
+	fieldErr := jsonxErr.NewFieldErr(errorName, msg, opts...)
+	fieldErrMap[fieldErr.Name()] = fieldErr
+
+	return fieldErr
 }
 
-func exchangeIfFieldError(tag reflect.StructTag, err error) error {
-	fieldErrName := tag.Get("fieldErr")
+// exchangeIfFieldError
+// fieldErrTag is either a single field error name ("nameErr") or a mapping per annotation
+// ("NotBlank=nameRequired,Length=nameTooLong,pattern=nameFormat,nameErr").
+// a name without annotation is used for annotations that are not mapped.
+// the replaced error stays reachable by errors.Unwrap.
+func exchangeIfFieldError(fieldErrTag string, err error) error {
+	if fieldErrTag == "" {
+		return err
+	}
 
-	if fieldErrName == "" {
+	mapping, fallback, parseErr := parseFieldErrTag(fieldErrTag)
+	if parseErr != nil {
 		return err
 	}
 
-	if fieldErr, ok := fieldErrMap[fieldErrName]; ok {
-		return &fieldErr
+	var validationErr *jsonxErr.ValidationError
+	isValidationErr := errors.As(err, &validationErr)
+
+	fieldErrName := fallback
+	if isValidationErr {
+		if name, ok := mapping[validationErr.Annotation]; ok {
+			fieldErrName = name
+		}
+	}
+
+	fieldErr, ok := fieldErrMap[fieldErrName]
+	if !ok {
+		return err
+	}
+
+	if isValidationErr {
+		return fieldErr.
+			WithParams(validationErr.TemplateParams()).
+			WithField(validationErr.Field).
+			WithCause(err)
+	}
+
+	return fieldErr.WithCause(err)
+}
+
+// parseFieldErrTag
+// "NotBlank=nameRequired,nameErr" -> {"NotBlank": "nameRequired"}, "nameErr"
+func parseFieldErrTag(fieldErrTag string) (map[string]string, string, error) {
+	mapping := map[string]string{}
+	fallback := ""
+
+	for _, entry := range strings.Split(fieldErrTag, ",") {
+		entry = strings.TrimSpace(entry)
+		annotation, name, isMapping := strings.Cut(entry, "=")
+		annotation = strings.TrimPrefix(strings.TrimSpace(annotation), "@")
+		name = strings.TrimSpace(name)
+
+		switch {
+		case entry == "":
+			return nil, "", errors.New("empty field error entry")
+		case !isMapping:
+			if fallback != "" {
+				return nil, "", fmt.Errorf("more than one default field error (%s, %s)", fallback, entry)
+			}
+			fallback = entry
+		case annotation == "" || name == "":
+			return nil, "", fmt.Errorf("wrong field error mapping %q", entry)
+		default:
+			if _, ok := mapping[annotation]; ok {
+				return nil, "", fmt.Errorf("duplicate field error mapping for %s", annotation)
+			}
+			mapping[annotation] = name
+		}
 	}
 
-	return err
+	return mapping, fallback, nil
 }
 
 func Close() {
 	validatorMap = map[reflect.Type]any{}
 	orderedValidatorMap = typex.NewMultiMap[reflect.Type, any]()
-	fieldErrMap = map[string]jsonxErr.FieldError{}
+	fieldErrMap = map[string]*jsonxErr.FieldError{}
+	registeredTypes = map[reflect.Type]bool{}
+	schemaHooks = map[string]SchemaHook{}
+	codeRules = map[reflect.Type]map[string]*fieldRule{}
+	fileRules = map[reflect.Type]map[string]*fieldRule{}
+	definitions.SetClock(nil)
+	message.Reset()
 }
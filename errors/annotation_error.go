@@ -0,0 +1,58 @@
+package errors
+
+// AnnotationReason
+// stable code of an AnnotationError, safe to branch on
+type AnnotationReason string
+
+const (
+	// ReasonNil
+	// the value is nil
+	ReasonNil AnnotationReason = "nil"
+	// ReasonEmpty
+	// the string or container has no content
+	ReasonEmpty AnnotationReason = "empty"
+	// ReasonBlank
+	// the string has only whitespace
+	ReasonBlank AnnotationReason = "blank"
+	// ReasonWrongType
+	// the annotation does not support the type of the value
+	ReasonWrongType AnnotationReason = "wrong_type"
+	// ReasonFormat
+	// the string does not follow the grammar of the annotation
+	ReasonFormat AnnotationReason = "format"
+	// ReasonRange
+	// the number, length, size or time is outside the bounds of the annotation
+	ReasonRange AnnotationReason = "range"
+	// ReasonNotAllowed
+	// the value is not in the allowlist of the annotation or is denied by it
+	ReasonNotAllowed AnnotationReason = "not_allowed"
+)
+
+// AnnotationError
+// a built-in annotation rejected a value
+type AnnotationError struct {
+	// Annotation is the name without "@", e.g. "Email"
+	Annotation string
+	Reason     AnnotationReason
+	// Value is the rejected value, the element for slices, arrays and maps
+	Value any
+	// Detail describes the violation for humans, e.g. "missing '@'"
+	Detail string
+}
+
+func NewAnnotationErr(annotation string, reason AnnotationReason, value any, detail string) *AnnotationError {
+	return &AnnotationError{
+		Annotation: annotation,
+		Reason:     reason,
+		Value:      value,
+		Detail:     detail,
+	}
+}
+
+func (e *AnnotationError) Error() string {
+	if e.Detail == "" {
+		return "@" + e.Annotation + " " + string(e.Reason)
+	}
+
+	return "@" + e.Annotation + " " + e.Detail
+}
@@ -0,0 +1,30 @@
+package errors
+
+import "fmt"
+
+// BindError
+// a query parameter, header or path value could not be converted to its field type
+type BindError struct {
+	// Source is the tag the field was bound with: "query", "header", "path" or "form"
+	Source string
+	Key    string
+	Value  string
+	Err    error
+}
+
+func NewBindErr(source, key, value string, err error) *BindError {
+	return &BindError{
+		Source: source,
+		Key:    key,
+		Value:  value,
+		Err:    err,
+	}
+}
+
+func (e *BindError) Error() string {
+	return fmt.Sprintf("%s %s=%q: %s", e.Source, e.Key, e.Value, e.Err)
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
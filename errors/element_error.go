@@ -0,0 +1,26 @@
+package errors
+
+// ElementError
+// an annotation rejected one element of a slice, array or map field
+type ElementError struct {
+	// Index is appended to the field path: "[0]" for slices and arrays, ".key" for maps
+	Index string
+	Value any
+	Err   error
+}
+
+func NewElementErr(index string, value any, err error) *ElementError {
+	return &ElementError{
+		Index: index,
+		Value: value,
+		Err:   err,
+	}
+}
+
+func (e *ElementError) Error() string {
+	return e.Index + ": " + e.Err.Error()
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
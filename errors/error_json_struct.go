@@ -1,7 +1,11 @@
 package errors
 
 type errorJsonStruct struct {
-	FrameworkName string `json:"framework"`
-	Name          string `json:"errorName"`
-	Msg           string `json:"Msg"`
+	FrameworkName string            `json:"framework"`
+	Name          string            `json:"errorName"`
+	Msg           string            `json:"Msg"`
+	Code          string            `json:"code,omitempty"`
+	Status        int               `json:"status,omitempty"`
+	Field         string            `json:"field,omitempty"`
+	Params        map[string]string `json:"params,omitempty"`
 }
@@ -0,0 +1,7 @@
+package errors
+
+import "errors"
+
+// ErrUnmarshal
+// joined with the encoding/json error when jsonx.Unmarshal can not decode the data
+var ErrUnmarshal = errors.New("fail to unmarshal")
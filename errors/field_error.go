@@ -2,34 +2,194 @@ package errors
 
 import (
 	"encoding/json"
+	"github.com/aivyss/jsonx/constant"
+	"github.com/aivyss/jsonx/message"
 )
 
 type FieldError struct {
 	defaultMsg string
 	name       string
+	framework  string
+	code       string
+	status     int
+	field      string
+	params     map[string]string
+	cause      error
 }
 
-func NewFieldErr(errorName, defaultMsg string) *FieldError {
-	return &FieldError{
+// FieldErrOption
+// configures a FieldError at registration
+type FieldErrOption func(e *FieldError)
+
+// WithCode
+// a stable machine readable code, Name is used when it is not set
+func WithCode(code string) FieldErrOption {
+	return func(e *FieldError) {
+		e.code = code
+	}
+}
+
+// WithFramework
+// the "framework" written by Error and MarshalJSON, "jsonx" when it is not set
+func WithFramework(framework string) FieldErrOption {
+	return func(e *FieldError) {
+		e.framework = framework
+	}
+}
+
+// WithStatus
+// the HTTP status to respond with, e.g. http.StatusUnprocessableEntity
+func WithStatus(status int) FieldErrOption {
+	return func(e *FieldError) {
+		e.status = status
+	}
+}
+
+// WithParams
+// static template params, e.g. {"max": "50"}
+func WithParams(params map[string]string) FieldErrOption {
+	return func(e *FieldError) {
+		e.params = mergeParams(e.params, params)
+	}
+}
+
+func NewFieldErr(errorName, defaultMsg string, opts ...FieldErrOption) *FieldError {
+	fieldErr := &FieldError{
 		name:       errorName,
 		defaultMsg: defaultMsg,
 	}
+
+	for _, opt := range opts {
+		opt(fieldErr)
+	}
+
+	return fieldErr
 }
 
 func (e *FieldError) Name() string {
 	return e.name
 }
 
+// DefaultMsg
+// the registered message template, placeholders are not rendered
 func (e *FieldError) DefaultMsg() string {
 	return e.defaultMsg
 }
 
-func (e *FieldError) Error() string {
-	j, _ := json.Marshal(errorJsonStruct{
-		FrameworkName: "jsonx",
+// Code
+// the registered code or Name
+func (e *FieldError) Code() string {
+	if e.code == "" {
+		return e.name
+	}
+
+	return e.code
+}
+
+// Status
+// the registered HTTP status, 0 if not set
+func (e *FieldError) Status() int {
+	return e.status
+}
+
+// Field
+// JSON path of the field which failed, empty for a registered sentinel
+func (e *FieldError) Field() string {
+	return e.field
+}
+
+// Params
+// values for the placeholders of the message template, e.g. {field}
+func (e *FieldError) Params() map[string]string {
+	return e.params
+}
+
+// WithParams
+// returns a copy of the error with params added to the registered ones
+func (e *FieldError) WithParams(params map[string]string) *FieldError {
+	copied := *e
+	copied.params = mergeParams(e.params, params)
+
+	return &copied
+}
+
+// WithField
+// returns a copy of the error for the field path
+func (e *FieldError) WithField(field string) *FieldError {
+	copied := *e
+	copied.field = field
+
+	return &copied
+}
+
+// WithCause
+// returns a copy of the error wrapping cause
+func (e *FieldError) WithCause(cause error) *FieldError {
+	copied := *e
+	copied.cause = cause
+
+	return &copied
+}
+
+// Unwrap
+// the validation error replaced by this field error
+func (e *FieldError) Unwrap() error {
+	return e.cause
+}
+
+// Is
+// copies made by With* match their registered sentinel,
+// so errors.Is(err, sentinel) works for errors returned by Validate
+func (e *FieldError) Is(target error) bool {
+	t, ok := target.(*FieldError)
+	if !ok || t == nil {
+		return false
+	}
+
+	return t.name == e.name
+}
+
+// Msg
+// DefaultMsg rendered with Params
+func (e *FieldError) Msg() string {
+	return message.Render(e.defaultMsg, e.params)
+}
+
+func (e *FieldError) MarshalJSON() ([]byte, error) {
+	framework := e.framework
+	if framework == "" {
+		framework = constant.FrameworkName
+	}
+
+	return json.Marshal(errorJsonStruct{
+		FrameworkName: framework,
 		Name:          e.name,
-		Msg:           e.defaultMsg,
+		Msg:           e.Msg(),
+		Code:          e.code,
+		Status:        e.status,
+		Field:         e.field,
+		Params:        e.params,
 	})
+}
+
+func (e *FieldError) Error() string {
+	j, _ := e.MarshalJSON()
 
 	return string(j)
 }
+
+func mergeParams(base, params map[string]string) map[string]string {
+	if len(params) == 0 {
+		return base
+	}
+
+	merged := make(map[string]string, len(base)+len(params))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range params {
+		merged[k] = v
+	}
+
+	return merged
+}
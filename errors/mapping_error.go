@@ -0,0 +1,35 @@
+package errors
+
+import "fmt"
+
+// MappingError
+// a problem of a constraint mapping file loaded by jsonx.LoadRuleFile
+type MappingError struct {
+	File string
+	// Type is the type name used in the file
+	Type string
+	// Field is the JSON field name used in the file, empty for problems of the type
+	Field string
+	Msg   string
+}
+
+func NewMappingErr(file, typeName, field, msg string) *MappingError {
+	return &MappingError{
+		File:  file,
+		Type:  typeName,
+		Field: field,
+		Msg:   msg,
+	}
+}
+
+func (e *MappingError) Error() string {
+	location := e.Type
+	if e.Field != "" {
+		location += "." + e.Field
+	}
+	if e.File != "" {
+		location = e.File + ": " + location
+	}
+
+	return fmt.Sprintf("%s: %s", location, e.Msg)
+}
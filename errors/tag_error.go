@@ -0,0 +1,25 @@
+package errors
+
+import "fmt"
+
+// TagError
+// a misconfigured struct tag found by jsonx.Check
+type TagError struct {
+	Type  string
+	Field string
+	Tag   string
+	Msg   string
+}
+
+func NewTagErr(typeName, field, tag, msg string) *TagError {
+	return &TagError{
+		Type:  typeName,
+		Field: field,
+		Tag:   tag,
+		Msg:   msg,
+	}
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("%s.%s: %s tag: %s", e.Type, e.Field, e.Tag, e.Msg)
+}
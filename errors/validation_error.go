@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ValidationError
+// an annotation or pattern tag rejected the value of a field
+type ValidationError struct {
+	// Field is the JSON path of the field, e.g. "address.street"
+	Field string
+	// Annotation is the name of the failed annotation ("NotBlank") or "pattern"
+	Annotation string
+	// Params are the annotation arguments used by message templates, e.g. {"min": "1"}
+	Params map[string]string
+	Value  any
+	Err    error
+}
+
+func NewValidationErr(annotation string, params map[string]string, value any, err error) *ValidationError {
+	return &ValidationError{
+		Annotation: annotation,
+		Params:     params,
+		Value:      value,
+		Err:        err,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// TemplateParams
+// Params with {field}, {value} and {annotation}
+func (e *ValidationError) TemplateParams() map[string]string {
+	params := make(map[string]string, len(e.Params)+3)
+	for k, v := range e.Params {
+		params[k] = v
+	}
+	params["field"] = e.Field
+	params["value"] = formatValue(e.Value)
+	params["annotation"] = e.Annotation
+
+	return params
+}
+
+func formatValue(v any) string {
+	valueOf := reflect.ValueOf(v)
+	if !valueOf.IsValid() {
+		return "null"
+	}
+
+	if valueOf.Kind() == reflect.Pointer {
+		if valueOf.IsNil() {
+			return "null"
+		}
+		valueOf = valueOf.Elem()
+	}
+
+	return fmt.Sprint(valueOf.Interface())
+}
@@ -2,4 +2,10 @@ module github.com/aivyss/jsonx
 
 go 1.20
 
-require github.com/aivyss/typex v1.1.0
+require (
+	github.com/aivyss/typex v1.1.0
+	golang.org/x/net v0.33.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/text v0.21.0 // indirect
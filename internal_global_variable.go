@@ -8,4 +8,8 @@ import (
 
 var orderedValidatorMap = typex.NewMultiMap[reflect.Type, any]()
 var validatorMap = map[reflect.Type]any{}
-var fieldErrMap = map[string]errors.FieldError{}
+var fieldErrMap = map[string]*errors.FieldError{}
+var registeredTypes = map[reflect.Type]bool{}
+var schemaHooks = map[string]SchemaHook{}
+var codeRules = map[reflect.Type]map[string]*fieldRule{}
+var fileRules = map[reflect.Type]map[string]*fieldRule{}
@@ -0,0 +1,400 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type compiler struct {
+	draft      Draft
+	draftFixed bool
+	document   any
+	// nodes are cached by JSON pointer so that recursive references share a node
+	nodes map[string]*node
+	refs  []*node
+}
+
+func newCompiler(document any) *compiler {
+	return &compiler{
+		document: document,
+		nodes:    map[string]*node{},
+	}
+}
+
+func (c *compiler) detectDraft() {
+	if c.draftFixed {
+		return
+	}
+
+	if object, ok := c.document.(map[string]any); ok {
+		if dialect, ok := object["$schema"].(string); ok && strings.Contains(dialect, "draft-07") {
+			c.draft = Draft7
+		}
+	}
+}
+
+// compile
+// pointer is the JSON pointer of raw within the document
+func (c *compiler) compile(pointer string, raw any) (*node, error) {
+	if n, ok := c.nodes[pointer]; ok {
+		return n, nil
+	}
+
+	n := &node{}
+	c.nodes[pointer] = n
+
+	switch schema := raw.(type) {
+	case bool:
+		n.always = &schema
+		return n, nil
+	case map[string]any:
+		if err := c.compileObject(n, pointer, schema); err != nil {
+			return nil, err
+		}
+		return n, nil
+	default:
+		return nil, schemaErr(pointer, "schema must be an object or a boolean")
+	}
+}
+
+func (c *compiler) compileObject(n *node, pointer string, schema map[string]any) error {
+	if ref, ok := schema["$ref"]; ok {
+		value, ok := ref.(string)
+		if !ok {
+			return schemaErr(pointer+"/$ref", "must be a string")
+		}
+		n.ref = value
+		c.refs = append(c.refs, n)
+
+		if c.draft == Draft7 {
+			// draft-07 ignores the siblings of $ref
+			return nil
+		}
+	}
+
+	var errs []error
+	for _, keyword := range sortedKeys(schema) {
+		if err := c.compileKeyword(n, pointer, keyword, schema[keyword]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.draft == Draft7 {
+		if additional, ok := schema["additionalItems"]; ok && n.prefixItems != nil {
+			sub, err := c.compile(pointer+"/additionalItems", additional)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			n.items = sub
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *compiler) compileKeyword(n *node, pointer, keyword string, value any) error {
+	at := pointer + "/" + escapePointer(keyword)
+
+	var err error
+	switch keyword {
+	case "type":
+		n.types, err = typeNames(at, value)
+	case "enum":
+		values, ok := value.([]any)
+		if !ok {
+			return schemaErr(at, "must be an array")
+		}
+		n.enum = values
+		n.hasEnum = true
+	case "const":
+		n.constValue = value
+		n.hasConst = true
+	case "properties":
+		n.properties, err = c.compileMap(at, value)
+	case "patternProperties":
+		var schemas map[string]*node
+		if schemas, err = c.compileMap(at, value); err != nil {
+			return err
+		}
+		for _, pattern := range sortedKeys(schemas) {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return schemaErr(at, err.Error())
+			}
+			n.patternProperties = append(n.patternProperties, patternNode{pattern: re, node: schemas[pattern]})
+		}
+	case "additionalProperties":
+		n.additionalProperties, err = c.compile(at, value)
+	case "required":
+		n.required, err = stringsOf(at, value)
+	case "minimum":
+		n.minimum, err = numberOf(at, value)
+	case "maximum":
+		n.maximum, err = numberOf(at, value)
+	case "exclusiveMinimum":
+		n.exclusiveMinimum, err = numberOf(at, value)
+	case "exclusiveMaximum":
+		n.exclusiveMaximum, err = numberOf(at, value)
+	case "multipleOf":
+		if n.multipleOf, err = numberOf(at, value); err == nil && n.multipleOf.Sign() <= 0 {
+			err = schemaErr(at, "must be greater than 0")
+		}
+	case "minLength":
+		n.minLength, err = countOf(at, value)
+	case "maxLength":
+		n.maxLength, err = countOf(at, value)
+	case "minItems":
+		n.minItems, err = countOf(at, value)
+	case "maxItems":
+		n.maxItems, err = countOf(at, value)
+	case "minProperties":
+		n.minProperties, err = countOf(at, value)
+	case "maxProperties":
+		n.maxProperties, err = countOf(at, value)
+	case "uniqueItems":
+		unique, ok := value.(bool)
+		if !ok {
+			return schemaErr(at, "must be a boolean")
+		}
+		n.uniqueItems = unique
+	case "pattern":
+		pattern, ok := value.(string)
+		if !ok {
+			return schemaErr(at, "must be a string")
+		}
+		if n.pattern, err = regexp.Compile(pattern); err != nil {
+			err = schemaErr(at, err.Error())
+		}
+	case "items":
+		if _, isArray := value.([]any); isArray && c.draft == Draft7 {
+			n.prefixItems, err = c.compileList(at, value)
+		} else {
+			n.items, err = c.compile(at, value)
+		}
+	case "prefixItems":
+		if c.draft == Draft2020 {
+			n.prefixItems, err = c.compileList(at, value)
+		}
+	case "allOf":
+		n.allOf, err = c.compileList(at, value)
+	case "anyOf":
+		n.anyOf, err = c.compileList(at, value)
+	case "oneOf":
+		n.oneOf, err = c.compileList(at, value)
+	case "not":
+		n.not, err = c.compile(at, value)
+	}
+
+	return err
+}
+
+func (c *compiler) compileList(pointer string, value any) ([]*node, error) {
+	schemas, ok := value.([]any)
+	if !ok {
+		return nil, schemaErr(pointer, "must be an array")
+	}
+
+	nodes := make([]*node, 0, len(schemas))
+	for i, schema := range schemas {
+		sub, err := c.compile(pointer+"/"+strconv.Itoa(i), schema)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, sub)
+	}
+
+	return nodes, nil
+}
+
+func (c *compiler) compileMap(pointer string, value any) (map[string]*node, error) {
+	schemas, ok := value.(map[string]any)
+	if !ok {
+		return nil, schemaErr(pointer, "must be an object")
+	}
+
+	nodes := make(map[string]*node, len(schemas))
+	for name, schema := range schemas {
+		sub, err := c.compile(pointer+"/"+escapePointer(name), schema)
+		if err != nil {
+			return nil, err
+		}
+		nodes[name] = sub
+	}
+
+	return nodes, nil
+}
+
+// resolveRefs
+// resolving a reference can compile a part of the document that holds further references
+func (c *compiler) resolveRefs() error {
+	for i := 0; i < len(c.refs); i++ {
+		n := c.refs[i]
+
+		pointer, err := refPointer(n.ref)
+		if err != nil {
+			return err
+		}
+		raw, err := c.lookup(pointer)
+		if err != nil {
+			return fmt.Errorf("unresolvable $ref %q: %w", n.ref, err)
+		}
+		if n.target, err = c.compile(pointer, raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *compiler) lookup(pointer string) (any, error) {
+	current := c.document
+	if pointer == "" {
+		return current, nil
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapePointer(token)
+
+		switch value := current.(type) {
+		case map[string]any:
+			next, ok := value[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			current = next
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil, fmt.Errorf("no element %q", token)
+			}
+			current = value[index]
+		default:
+			return nil, fmt.Errorf("cannot descend into %q", token)
+		}
+	}
+
+	return current, nil
+}
+
+// refPointer
+// only references within the document ("#", "#/$defs/name") are supported
+func refPointer(ref string) (string, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return "", fmt.Errorf("unsupported $ref %q: only references within the document are supported", ref)
+	}
+
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return "", fmt.Errorf("invalid $ref %q: %w", ref, err)
+	}
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return "", fmt.Errorf("unsupported $ref %q: anchors are not supported", ref)
+	}
+
+	return pointer, nil
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+func schemaErr(pointer, msg string) error {
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	return fmt.Errorf("invalid schema at %s: %s", pointer, msg)
+}
+
+var typeNameSet = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true,
+}
+
+func typeNames(pointer string, value any) ([]string, error) {
+	var names []string
+	switch typeValue := value.(type) {
+	case string:
+		names = []string{typeValue}
+	case []any:
+		for _, elem := range typeValue {
+			name, ok := elem.(string)
+			if !ok {
+				return nil, schemaErr(pointer, "must be a string or an array of strings")
+			}
+			names = append(names, name)
+		}
+	default:
+		return nil, schemaErr(pointer, "must be a string or an array of strings")
+	}
+
+	for _, name := range names {
+		if !typeNameSet[name] {
+			return nil, schemaErr(pointer, fmt.Sprintf("unknown type %q", name))
+		}
+	}
+
+	return names, nil
+}
+
+func stringsOf(pointer string, value any) ([]string, error) {
+	values, ok := value.([]any)
+	if !ok {
+		return nil, schemaErr(pointer, "must be an array of strings")
+	}
+
+	result := make([]string, 0, len(values))
+	for _, elem := range values {
+		s, ok := elem.(string)
+		if !ok {
+			return nil, schemaErr(pointer, "must be an array of strings")
+		}
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+func numberOf(pointer string, value any) (*big.Rat, error) {
+	if _, ok := value.(json.Number); !ok {
+		return nil, schemaErr(pointer, "must be a number")
+	}
+
+	r, ok := ratOf(value)
+	if !ok {
+		return nil, schemaErr(pointer, "must be a number")
+	}
+
+	return r, nil
+}
+
+func countOf(pointer string, value any) (*int, error) {
+	r, err := numberOf(pointer, value)
+	if err != nil || !r.IsInt() || r.Sign() < 0 || !r.Num().IsInt64() {
+		return nil, schemaErr(pointer, "must be a non-negative integer")
+	}
+
+	count := int(r.Num().Int64())
+	return &count, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
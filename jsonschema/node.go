@@ -0,0 +1,346 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	jsonxErr "github.com/aivyss/jsonx/errors"
+)
+
+type patternNode struct {
+	pattern *regexp.Regexp
+	node    *node
+}
+
+// node
+// a compiled schema, unset keywords are nil
+type node struct {
+	// always is set for the boolean schemas true and false
+	always *bool
+
+	ref    string
+	target *node
+
+	types      []string
+	enum       []any
+	hasEnum    bool
+	constValue any
+	hasConst   bool
+
+	properties           map[string]*node
+	patternProperties    []patternNode
+	additionalProperties *node
+	required             []string
+	minProperties        *int
+	maxProperties        *int
+
+	minimum          *big.Rat
+	maximum          *big.Rat
+	exclusiveMinimum *big.Rat
+	exclusiveMaximum *big.Rat
+	multipleOf       *big.Rat
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	prefixItems []*node
+	items       *node
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	allOf []*node
+	anyOf []*node
+	oneOf []*node
+	not   *node
+}
+
+func (n *node) validate(v any, path string) []error {
+	if n.always != nil {
+		if *n.always {
+			return nil
+		}
+		return []error{violation(path, "false", nil, v, "no value is allowed")}
+	}
+
+	var errs []error
+	if n.target != nil {
+		errs = append(errs, n.target.validate(v, path)...)
+	}
+
+	if len(n.types) > 0 && !matchesType(v, n.types) {
+		expected := strings.Join(n.types, ", ")
+		errs = append(errs, violation(path, "type", map[string]string{"type": expected}, v,
+			fmt.Sprintf("expected %s but got %s", expected, typeOf(v))))
+	}
+	if n.hasEnum && !contains(n.enum, v) {
+		errs = append(errs, violation(path, "enum", nil, v, "not one of the allowed values"))
+	}
+	if n.hasConst && !equal(n.constValue, v) {
+		errs = append(errs, violation(path, "const", nil, v, "not the constant value"))
+	}
+
+	switch value := v.(type) {
+	case map[string]any:
+		errs = append(errs, n.validateObject(value, path)...)
+	case []any:
+		errs = append(errs, n.validateArray(value, path)...)
+	case string:
+		errs = append(errs, n.validateString(value, path)...)
+	default:
+		if r, ok := ratOf(v); ok {
+			errs = append(errs, n.validateNumber(r, v, path)...)
+		}
+	}
+
+	return append(errs, n.validateCombinators(v, path)...)
+}
+
+// validateIn
+// a false subschema is reported under the keyword that holds it, e.g. additionalProperties
+func (n *node) validateIn(keyword string, v any, path string) []error {
+	if n.always != nil && !*n.always {
+		return []error{violation(path, keyword, nil, v, "value is not allowed")}
+	}
+
+	return n.validate(v, path)
+}
+
+func (n *node) validateObject(object map[string]any, path string) []error {
+	var errs []error
+	for _, name := range n.required {
+		if _, ok := object[name]; !ok {
+			errs = append(errs, violation(joinProperty(path, name), "required", nil, nil, "required property is missing"))
+		}
+	}
+	if n.minProperties != nil && len(object) < *n.minProperties {
+		errs = append(errs, violation(path, "minProperties", countParam("min", *n.minProperties), object,
+			fmt.Sprintf("must have at least %d properties", *n.minProperties)))
+	}
+	if n.maxProperties != nil && len(object) > *n.maxProperties {
+		errs = append(errs, violation(path, "maxProperties", countParam("max", *n.maxProperties), object,
+			fmt.Sprintf("must have at most %d properties", *n.maxProperties)))
+	}
+
+	for _, name := range sortedKeys(object) {
+		value := object[name]
+		propertyPath := joinProperty(path, name)
+		matched := false
+
+		if sub, ok := n.properties[name]; ok {
+			matched = true
+			errs = append(errs, sub.validateIn("properties", value, propertyPath)...)
+		}
+		for _, patternProperty := range n.patternProperties {
+			if patternProperty.pattern.MatchString(name) {
+				matched = true
+				errs = append(errs, patternProperty.node.validateIn("patternProperties", value, propertyPath)...)
+			}
+		}
+		if !matched && n.additionalProperties != nil {
+			errs = append(errs, n.additionalProperties.validateIn("additionalProperties", value, propertyPath)...)
+		}
+	}
+
+	return errs
+}
+
+func (n *node) validateArray(array []any, path string) []error {
+	var errs []error
+	if n.minItems != nil && len(array) < *n.minItems {
+		errs = append(errs, violation(path, "minItems", countParam("min", *n.minItems), array,
+			fmt.Sprintf("must have at least %d items", *n.minItems)))
+	}
+	if n.maxItems != nil && len(array) > *n.maxItems {
+		errs = append(errs, violation(path, "maxItems", countParam("max", *n.maxItems), array,
+			fmt.Sprintf("must have at most %d items", *n.maxItems)))
+	}
+	if n.uniqueItems {
+		for i := 1; i < len(array); i++ {
+			if contains(array[:i], array[i]) {
+				errs = append(errs, violation(joinIndex(path, i), "uniqueItems", nil, array[i], "duplicate item"))
+			}
+		}
+	}
+
+	for i, item := range array {
+		switch {
+		case i < len(n.prefixItems):
+			errs = append(errs, n.prefixItems[i].validateIn("prefixItems", item, joinIndex(path, i))...)
+		case n.items != nil:
+			errs = append(errs, n.items.validateIn("items", item, joinIndex(path, i))...)
+		}
+	}
+
+	return errs
+}
+
+func (n *node) validateString(s, path string) []error {
+	var errs []error
+	length := utf8.RuneCountInString(s)
+	if n.minLength != nil && length < *n.minLength {
+		errs = append(errs, violation(path, "minLength", countParam("min", *n.minLength), s,
+			fmt.Sprintf("must be at least %d characters", *n.minLength)))
+	}
+	if n.maxLength != nil && length > *n.maxLength {
+		errs = append(errs, violation(path, "maxLength", countParam("max", *n.maxLength), s,
+			fmt.Sprintf("must be at most %d characters", *n.maxLength)))
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		errs = append(errs, violation(path, "pattern", map[string]string{"pattern": n.pattern.String()}, s,
+			"not matched (pattern)"))
+	}
+
+	return errs
+}
+
+func (n *node) validateNumber(r *big.Rat, v any, path string) []error {
+	var errs []error
+	if n.minimum != nil && r.Cmp(n.minimum) < 0 {
+		errs = append(errs, violation(path, "minimum", ratParam("min", n.minimum), v,
+			"must be greater than or equal to "+ratString(n.minimum)))
+	}
+	if n.maximum != nil && r.Cmp(n.maximum) > 0 {
+		errs = append(errs, violation(path, "maximum", ratParam("max", n.maximum), v,
+			"must be less than or equal to "+ratString(n.maximum)))
+	}
+	if n.exclusiveMinimum != nil && r.Cmp(n.exclusiveMinimum) <= 0 {
+		errs = append(errs, violation(path, "exclusiveMinimum", ratParam("min", n.exclusiveMinimum), v,
+			"must be greater than "+ratString(n.exclusiveMinimum)))
+	}
+	if n.exclusiveMaximum != nil && r.Cmp(n.exclusiveMaximum) >= 0 {
+		errs = append(errs, violation(path, "exclusiveMaximum", ratParam("max", n.exclusiveMaximum), v,
+			"must be less than "+ratString(n.exclusiveMaximum)))
+	}
+	if n.multipleOf != nil && !new(big.Rat).Quo(r, n.multipleOf).IsInt() {
+		errs = append(errs, violation(path, "multipleOf", ratParam("multipleOf", n.multipleOf), v,
+			"must be a multiple of "+ratString(n.multipleOf)))
+	}
+
+	return errs
+}
+
+func (n *node) validateCombinators(v any, path string) []error {
+	var errs []error
+	for _, sub := range n.allOf {
+		errs = append(errs, sub.validate(v, path)...)
+	}
+
+	if len(n.anyOf) > 0 {
+		var causes []error
+		for _, sub := range n.anyOf {
+			subErrs := sub.validate(v, path)
+			if len(subErrs) == 0 {
+				causes = nil
+				break
+			}
+			causes = append(causes, subErrs...)
+		}
+		if causes != nil {
+			errs = append(errs, violation(path, "anyOf", nil, v, "must match at least one schema", causes...))
+		}
+	}
+
+	if len(n.oneOf) > 0 {
+		var causes []error
+		matches := 0
+		for _, sub := range n.oneOf {
+			subErrs := sub.validate(v, path)
+			if len(subErrs) == 0 {
+				matches++
+			}
+			causes = append(causes, subErrs...)
+		}
+		switch {
+		case matches == 0:
+			errs = append(errs, violation(path, "oneOf", nil, v, "must match exactly one schema", causes...))
+		case matches > 1:
+			errs = append(errs, violation(path, "oneOf", nil, v,
+				fmt.Sprintf("must match exactly one schema but matched %d", matches)))
+		}
+	}
+
+	if n.not != nil && len(n.not.validate(v, path)) == 0 {
+		errs = append(errs, violation(path, "not", nil, v, "must not match the schema"))
+	}
+
+	return errs
+}
+
+// violation
+// the causes of anyOf and oneOf are joined into Err
+func violation(path, keyword string, params map[string]string, value any, msg string, causes ...error) error {
+	err := errors.New(msg)
+	if len(causes) > 0 {
+		err = errors.Join(append([]error{err}, causes...)...)
+	}
+
+	validationErr := jsonxErr.NewValidationErr(keyword, params, value, err)
+	validationErr.Field = path
+
+	return validationErr
+}
+
+func matchesType(v any, types []string) bool {
+	actual := typeOf(v)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []any, v any) bool {
+	for _, value := range values {
+		if equal(value, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinProperty(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func joinIndex(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+func countParam(name string, count int) map[string]string {
+	return map[string]string{name: strconv.Itoa(count)}
+}
+
+func ratParam(name string, r *big.Rat) map[string]string {
+	return map[string]string{name: ratString(r)}
+}
+
+// ratString
+// shortest exact decimal form, a fraction such as 1/3 is kept as it is
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	for prec := 1; prec <= 64; prec++ {
+		decimal := r.FloatString(prec)
+		if back, ok := new(big.Rat).SetString(decimal); ok && back.Cmp(r) == 0 {
+			return decimal
+		}
+	}
+
+	return r.RatString()
+}
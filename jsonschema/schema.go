@@ -0,0 +1,110 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+)
+
+// Draft
+// the JSON Schema dialect a document is compiled with
+type Draft int
+
+const (
+	// Draft2020 draft 2020-12: $ref applies together with its siblings, tuples use prefixItems
+	Draft2020 Draft = iota
+	// Draft7 draft-07: $ref overrides its siblings, tuples use the array form of items
+	Draft7
+)
+
+type Option func(c *compiler)
+
+// WithDraft
+// Draft2020 unless the document declares a draft-07 $schema
+func WithDraft(draft Draft) Option {
+	return func(c *compiler) {
+		c.draft = draft
+		c.draftFixed = true
+	}
+}
+
+// Schema
+// a compiled schema document.
+// supported keywords: type, enum, const, properties, patternProperties, additionalProperties, required,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, minLength, maxLength, pattern,
+// items, prefixItems, additionalItems, minItems, maxItems, minProperties, maxProperties,
+// allOf, anyOf, oneOf, not and $ref to a JSON pointer within the document.
+type Schema struct {
+	root *node
+}
+
+// Compile
+// fails on malformed keywords, invalid patterns and unresolvable references
+func Compile(document []byte, opts ...Option) (*Schema, error) {
+	raw, err := decode(document)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid schema document"), err)
+	}
+
+	c := newCompiler(raw)
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.detectDraft()
+
+	root, err := c.compile("", raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.resolveRefs(); err != nil {
+		return nil, err
+	}
+
+	return &Schema{root: root}, nil
+}
+
+func MustCompile(document []byte, opts ...Option) *Schema {
+	schema, err := Compile(document, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return schema
+}
+
+// Validate
+// validates raw JSON. every violation is a *errors.ValidationError whose Field is the
+// path of the value ("items[0].name") and whose Annotation is the failed keyword.
+// they are joined with errors.Join.
+func (s *Schema) Validate(data []byte) error {
+	v, err := decode(data)
+	if err != nil {
+		return errors.Join(jsonxErr.ErrUnmarshal, err)
+	}
+
+	return s.ValidateValue(v)
+}
+
+// ValidateValue
+// validates decoded JSON such as map[string]any, []any, string, float64, json.Number, bool or nil
+func (s *Schema) ValidateValue(v any) error {
+	violations := s.root.validate(normalize(v), "")
+
+	return errors.Join(violations...)
+}
+
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("trailing data after JSON value")
+	}
+
+	return v, nil
+}
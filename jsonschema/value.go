@@ -0,0 +1,131 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// normalize
+// converts Go numbers to json.Number and slices and maps of any kind to []any and map[string]any
+func normalize(v any) any {
+	switch value := v.(type) {
+	case nil, bool, string, json.Number:
+		return value
+	case float64:
+		return json.Number(strconv.FormatFloat(value, 'g', -1, 64))
+	case float32:
+		return json.Number(strconv.FormatFloat(float64(value), 'g', -1, 32))
+	case []any:
+		normalized := make([]any, len(value))
+		for i, elem := range value {
+			normalized[i] = normalize(elem)
+		}
+		return normalized
+	case map[string]any:
+		normalized := make(map[string]any, len(value))
+		for k, elem := range value {
+			normalized[k] = normalize(elem)
+		}
+		return normalized
+	}
+
+	valueOf := reflect.ValueOf(v)
+	switch valueOf.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(valueOf.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(valueOf.Uint(), 10))
+	case reflect.Slice, reflect.Array:
+		normalized := make([]any, valueOf.Len())
+		for i := range normalized {
+			normalized[i] = normalize(valueOf.Index(i).Interface())
+		}
+		return normalized
+	case reflect.Map:
+		normalized := make(map[string]any, valueOf.Len())
+		iter := valueOf.MapRange()
+		for iter.Next() {
+			normalized[iter.Key().String()] = normalize(iter.Value().Interface())
+		}
+		return normalized
+	}
+
+	return v
+}
+
+// ratOf
+// exact value of a JSON number, 0.1 is 1/10
+func ratOf(v any) (*big.Rat, bool) {
+	number, ok := v.(json.Number)
+	if !ok {
+		return nil, false
+	}
+
+	return new(big.Rat).SetString(number.String())
+}
+
+// typeOf
+// JSON type name of a normalized value, numbers without fraction are "integer"
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if r, ok := ratOf(v); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
+
+// equal
+// JSON equality, 1 and 1.0 are equal
+func equal(a, b any) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		rx, okx := ratOf(x)
+		ry, oky := ratOf(y)
+		return okx && oky && rx.Cmp(ry) == 0
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,167 @@
+package jsonxhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/aivyss/jsonx"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultMaxBodyBytes
+	// body size limit of Bind and Handle when WithMaxBodyBytes is not given
+	DefaultMaxBodyBytes int64 = 1 << 20
+	// DefaultMaxMemory
+	// multipart files over it are stored in temporary files, see http.Request.ParseMultipartForm
+	DefaultMaxMemory int64 = 32 << 20
+)
+
+var (
+	// ErrUnsupportedMediaType the Content-Type is neither JSON nor a form
+	ErrUnsupportedMediaType = errors.New("content type must be application/json, application/x-www-form-urlencoded or multipart/form-data")
+	// ErrBodyTooLarge the body exceeds the size limit
+	ErrBodyTooLarge = errors.New("request body is too large")
+)
+
+type config struct {
+	maxBodyBytes int64
+	maxMemory    int64
+	pathValues   func(r *http.Request) map[string]string
+	handleError  func(w http.ResponseWriter, r *http.Request, err error)
+	successCode  int
+}
+
+type Option func(c *config)
+
+// WithMaxBodyBytes
+// body size limit, requests over it fail with ErrBodyTooLarge (413)
+func WithMaxBodyBytes(n int64) Option {
+	return func(c *config) {
+		c.maxBodyBytes = n
+	}
+}
+
+// WithMaxMemory
+// memory for multipart files, the rest of the body limit goes to temporary files
+func WithMaxMemory(n int64) Option {
+	return func(c *config) {
+		c.maxMemory = n
+	}
+}
+
+// WithPathValues
+// path parameters of the router for fields tagged `path:"id"`, e.g. mux.Vars
+func WithPathValues(pathValues func(r *http.Request) map[string]string) Option {
+	return func(c *config) {
+		c.pathValues = pathValues
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		maxBodyBytes: DefaultMaxBodyBytes,
+		maxMemory:    DefaultMaxMemory,
+		handleError:  writeError,
+		successCode:  http.StatusOK,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Bind
+// decodes the body of r into T, binds fields tagged `query`, `header` and `path`
+// and validates the result like jsonx.Unmarshal.
+// JSON bodies are decoded with their `json` tags, urlencoded and multipart forms with `form` tags.
+// a request without body and Content-Type skips the body decoding.
+func Bind[T any](r *http.Request, opts ...Option) (*T, error) {
+	c := newConfig(opts)
+	v := new(T)
+	sources := jsonx.Sources{Query: r.URL.Query(), Header: r.Header}
+
+	if hasBody(r) {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+		switch {
+		case isJSON(mediaType):
+			if err := decodeJSON(r, v, c.maxBodyBytes); err != nil {
+				return nil, err
+			}
+		case mediaType == "application/x-www-form-urlencoded":
+			r.Body = http.MaxBytesReader(nil, r.Body, c.maxBodyBytes)
+			if err := r.ParseForm(); err != nil {
+				return nil, formErr(err)
+			}
+			sources.Form = r.PostForm
+		case mediaType == "multipart/form-data":
+			r.Body = http.MaxBytesReader(nil, r.Body, c.maxBodyBytes)
+			if err := r.ParseMultipartForm(c.maxMemory); err != nil {
+				return nil, formErr(err)
+			}
+			sources.Form = r.MultipartForm.Value
+			sources.Files = r.MultipartForm.File
+		default:
+			return nil, ErrUnsupportedMediaType
+		}
+	}
+
+	if c.pathValues != nil {
+		sources.Path = c.pathValues(r)
+	}
+	if err := jsonx.DecodeValues(v, sources); err != nil {
+		return nil, err
+	}
+
+	if err := jsonx.Validate(*v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func decodeJSON(r *http.Request, v any, maxBodyBytes int64) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		return errors.Join(jsonxErr.ErrUnmarshal, err)
+	}
+	if int64(len(body)) > maxBodyBytes {
+		return ErrBodyTooLarge
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.Join(jsonxErr.ErrUnmarshal, err)
+	}
+
+	return nil
+}
+
+func formErr(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return ErrBodyTooLarge
+	}
+
+	return errors.Join(jsonxErr.ErrUnmarshal, err)
+}
+
+func hasBody(r *http.Request) bool {
+	if r.Body == nil || r.Body == http.NoBody {
+		return false
+	}
+
+	return r.ContentLength != 0 || r.Header.Get("Content-Type") != ""
+}
+
+// isJSON
+// application/json and application/*+json
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" ||
+		(strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json"))
+}
@@ -0,0 +1,118 @@
+package jsonxhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/problem"
+	"net/http"
+)
+
+// HandlerFunc
+// receives the bound and validated request body.
+// the returned value is written as JSON, nil writes 204 No Content.
+type HandlerFunc[T any] func(ctx context.Context, req *T) (any, error)
+
+// WithErrorHandler
+// replaces the default error writer, which writes problem details:
+// 415 for the Content-Type, 413 for the body size, 400 for malformed JSON,
+// 422 (or the FieldError status) for validation failures and 500 otherwise
+func WithErrorHandler(handle func(w http.ResponseWriter, r *http.Request, err error)) Option {
+	return func(c *config) {
+		c.handleError = handle
+	}
+}
+
+// WithRenderer
+// problem details renderer of the default error writer
+func WithRenderer(renderer *problem.Renderer) Option {
+	return func(c *config) {
+		c.handleError = func(w http.ResponseWriter, r *http.Request, err error) {
+			writeErrorWith(renderer, w, r, err)
+		}
+	}
+}
+
+// WithSuccessStatus
+// status of a non-nil response, 200 by default
+func WithSuccessStatus(status int) Option {
+	return func(c *config) {
+		c.successCode = status
+	}
+}
+
+// Handle
+// adapts fn to http.Handler: Bind, call fn, write the response or the error
+func Handle[T any](fn HandlerFunc[T], opts ...Option) http.Handler {
+	c := newConfig(opts)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := Bind[T](r, opts...)
+		if err != nil {
+			c.handleError(w, r, &invalidRequestErr{err: err})
+			return
+		}
+
+		resp, err := fn(r.Context(), req)
+		if err != nil {
+			c.handleError(w, r, err)
+			return
+		}
+
+		if resp == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		body, err := json.Marshal(resp)
+		if err != nil {
+			c.handleError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(c.successCode)
+		_, _ = w.Write(body)
+	})
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	writeErrorWith(problem.Default, w, r, err)
+}
+
+func writeErrorWith(renderer *problem.Renderer, w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrUnsupportedMediaType):
+		problem.WriteDetails(w, problem.New(http.StatusUnsupportedMediaType, err.Error()))
+	case errors.Is(err, ErrBodyTooLarge):
+		problem.WriteDetails(w, problem.New(http.StatusRequestEntityTooLarge, err.Error()))
+	case errors.As(err, new(*invalidRequestErr)) || isValidationErr(err):
+		renderer.Write(w, r, err)
+	default:
+		problem.WriteDetails(w, problem.New(http.StatusInternalServerError, ""))
+	}
+}
+
+// isValidationErr
+// errors of jsonx: decoding, annotations, patterns and field errors
+func isValidationErr(err error) bool {
+	var fieldErr *jsonxErr.FieldError
+	var validationErr *jsonxErr.ValidationError
+
+	return errors.Is(err, jsonxErr.ErrUnmarshal) || errors.As(err, &fieldErr) || errors.As(err, &validationErr)
+}
+
+// invalidRequestErr
+// marks errors of Bind, including those of registered validators, as client errors
+type invalidRequestErr struct {
+	err error
+}
+
+func (e *invalidRequestErr) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidRequestErr) Unwrap() error {
+	return e.err
+}
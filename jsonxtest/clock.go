@@ -0,0 +1,54 @@
+package jsonxtest
+
+import (
+	"github.com/aivyss/jsonx"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Clock
+// a fake clock for jsonx.SetClock, it only moves with Set and Advance
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// UseClock
+// installs a fake clock at now with jsonx.SetClock until the end of the test
+func UseClock(tb testing.TB, now time.Time) *Clock {
+	tb.Helper()
+
+	clock := NewClock(now)
+	jsonx.SetClock(clock)
+	tb.Cleanup(func() {
+		jsonx.SetClock(nil)
+	})
+
+	return clock
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
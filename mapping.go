@@ -0,0 +1,301 @@
+package jsonx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/aivyss/jsonx/definitions"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/tag"
+	"gopkg.in/yaml.v3"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ruleFile
+// types:
+//
+//	user.SignUpRequest:      # type name, "SignUpRequest" when it is unique
+//	  email:                 # JSON field name
+//	    annotations: ["@NotBlank", "@Length(1,100)"]   # or "@NotBlank @Length(1,100)"
+//	    pattern: "@example\\.com$"
+//	    fieldErr: "Length=emailTooLong,emailInvalid"
+//	    replace: true        # ignore the tags and Rules of the field
+type ruleFile struct {
+	Types map[string]map[string]ruleEntry `yaml:"types"`
+}
+
+type ruleEntry struct {
+	Annotations annotationList `yaml:"annotations"`
+	Pattern     string         `yaml:"pattern"`
+	FieldErr    string         `yaml:"fieldErr"`
+	Replace     bool           `yaml:"replace"`
+}
+
+// annotationList
+// a list of annotations or a string written like an annotation tag
+type annotationList []string
+
+func (l *annotationList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = annotationList{node.Value}
+		return nil
+	}
+
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+
+	return nil
+}
+
+// LoadRuleFile
+// loads a YAML or JSON mapping file, see LoadRules
+func LoadRuleFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return loadRules(path, data)
+}
+
+// LoadRules
+// assigns annotations, patterns and field errors to fields of the types registered by MustRegisterType
+// and the structs they contain, by type name and JSON field name. data is YAML or JSON.
+//
+// override policy, from weakest to strongest: struct tags, Rules, mapping files in loading order.
+// an annotation replaces the annotation of the same name, other annotations are kept,
+// a pattern or fieldErr replaces the previous one and replace: true drops everything before the file.
+// nothing is applied when the file has a problem.
+func LoadRules(data []byte) error {
+	return loadRules("", data)
+}
+
+func loadRules(file string, data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var content ruleFile
+	if err := decoder.Decode(&content); err != nil {
+		if file != "" {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		return err
+	}
+
+	types := mappableTypes()
+	staged := cloneRules(fileRules)
+	affected := map[reflect.Type]string{}
+	var problems []error
+
+	for _, typeName := range sortedKeys(content.Types) {
+		typeOf, err := lookupMappableType(types, typeName)
+		if err != nil {
+			problems = append(problems, jsonxErr.NewMappingErr(file, typeName, "", err.Error()))
+			continue
+		}
+		affected[typeOf] = typeName
+
+		fields := content.Types[typeName]
+		for _, jsonName := range sortedKeys(fields) {
+			field, ok := fieldByJSONName(typeOf, jsonName)
+			if !ok {
+				problems = append(problems, jsonxErr.NewMappingErr(file, typeName, jsonName, "unknown field"))
+				continue
+			}
+
+			entry := fields[jsonName]
+			if err := entry.validate(); err != nil {
+				problems = append(problems, jsonxErr.NewMappingErr(file, typeName, jsonName, err.Error()))
+				continue
+			}
+			entry.mergeInto(ruleOf(staged, typeOf, field.Name))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Join(problems...)
+	}
+
+	// the merged plans must pass Check, e.g. @Length on an int field
+	previous := fileRules
+	fileRules = staged
+	for typeOf, typeName := range affected {
+		c := &typeChecker{visited: map[reflect.Type]bool{}}
+		c.check(typeOf)
+		for _, problem := range c.problems {
+			var tagErr *jsonxErr.TagError
+			if errors.As(problem, &tagErr) && tagErr.Type == typeOf.String() {
+				problem = jsonxErr.NewMappingErr(file, typeName, jsonNameOf(typeOf, tagErr.Field), tagErr.Msg)
+			}
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		fileRules = previous
+		return errors.Join(problems...)
+	}
+
+	return nil
+}
+
+func (e ruleEntry) validate() error {
+	for _, annoStr := range tag.SplitAnnotationTag(e.annotationTag()) {
+		if _, err := definitions.ConvertToAnnotation(annoStr); err != nil {
+			return fmt.Errorf("@%s: %w", annoStr, err)
+		}
+	}
+
+	if e.Pattern != "" {
+		if _, err := regexp.Compile(e.Pattern); err != nil {
+			return fmt.Errorf("wrong regular expression: %w", err)
+		}
+	}
+
+	if e.FieldErr != "" {
+		if _, _, err := parseFieldErrTag(e.FieldErr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// annotationTag
+// ["@NotBlank", "Length(1,5)"] -> "@NotBlank @Length(1,5)"
+func (e ruleEntry) annotationTag() string {
+	annotations := make([]string, 0, len(e.Annotations))
+	for _, annotation := range e.Annotations {
+		annotation = strings.TrimSpace(annotation)
+		if !strings.HasPrefix(annotation, "@") {
+			annotation = "@" + annotation
+		}
+		annotations = append(annotations, annotation)
+	}
+
+	return strings.Join(annotations, " ")
+}
+
+func (e ruleEntry) mergeInto(rule *fieldRule) {
+	if e.Replace {
+		*rule = fieldRule{replace: true}
+	}
+
+	rule.annotations = mergeAnnotations(rule.annotations, e.annotationTag())
+	if e.Pattern != "" {
+		rule.pattern = e.Pattern
+	}
+	if e.FieldErr != "" {
+		rule.fieldErr = e.FieldErr
+	}
+}
+
+// mappableTypes
+// registered types and the struct types they contain
+func mappableTypes() []reflect.Type {
+	found := map[reflect.Type]bool{}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		if found[t] {
+			return
+		}
+		found[t] = true
+
+		for _, plan := range planOf(t) {
+			if plan.nested != nil {
+				walk(plan.nested)
+			}
+			if elem := containerElemStruct(plan.field.Type); elem != nil {
+				walk(elem)
+			}
+		}
+	}
+	for t := range registeredTypes {
+		walk(t)
+	}
+
+	types := make([]reflect.Type, 0, len(found))
+	for t := range found {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+
+	return types
+}
+
+// lookupMappableType
+// by package qualified name ("user.SignUpRequest") or by name when it is unique
+func lookupMappableType(types []reflect.Type, typeName string) (reflect.Type, error) {
+	var matches []reflect.Type
+	for _, t := range types {
+		if t.String() == typeName {
+			return t, nil
+		}
+		if t.Name() == typeName {
+			matches = append(matches, t)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, errors.New("unknown type, register it with MustRegisterType")
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, t := range matches {
+			names = append(names, t.String())
+		}
+		return nil, fmt.Errorf("ambiguous type name (%s)", strings.Join(names, ", "))
+	}
+}
+
+// fieldByJSONName
+// direct fields of the type, fields promoted from embedded structs belong to the embedded type
+func fieldByJSONName(typeOf reflect.Type, jsonName string) (reflect.StructField, bool) {
+	for _, plan := range planOf(typeOf) {
+		if plan.isVisible() && !plan.isJSONIgnored() && !plan.isPromoted() && plan.jsonName() == jsonName {
+			return plan.field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func jsonNameOf(typeOf reflect.Type, fieldName string) string {
+	if field, ok := typeOf.FieldByName(fieldName); ok {
+		return fieldPlan{field: field}.jsonName()
+	}
+
+	return fieldName
+}
+
+func cloneRules(rules map[reflect.Type]map[string]*fieldRule) map[reflect.Type]map[string]*fieldRule {
+	cloned := make(map[reflect.Type]map[string]*fieldRule, len(rules))
+	for t, fields := range rules {
+		cloned[t] = make(map[string]*fieldRule, len(fields))
+		for name, rule := range fields {
+			copied := *rule
+			cloned[t][name] = &copied
+		}
+	}
+
+	return cloned
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
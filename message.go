@@ -0,0 +1,63 @@
+package jsonx
+
+import (
+	"errors"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/message"
+)
+
+// RegisterMessages
+// adds message templates to a locale. keys are "@<Annotation>", "pattern" or a field error name.
+func RegisterMessages(locale string, messages map[string]string) {
+	message.Register(locale, messages)
+}
+
+// LoadMessageBundle
+// registers a JSON file of key to template for the locale
+func LoadMessageBundle(locale, path string) error {
+	return message.LoadFile(locale, path)
+}
+
+// LoadMessageBundles
+// registers every <locale>.json file in dir
+func LoadMessageBundles(dir string) error {
+	return message.LoadDir(dir)
+}
+
+// Message
+// localized message of an error returned by Unmarshal or Validate.
+// acceptLanguage is an Accept-Language value such as "ko-KR,ko;q=0.9,en;q=0.8".
+func Message(err error, acceptLanguage string) string {
+	if err == nil {
+		return ""
+	}
+	locales := message.ParseAcceptLanguage(acceptLanguage)
+
+	var fieldErr *jsonxErr.FieldError
+	if errors.As(err, &fieldErr) {
+		if template, ok := message.Lookup(fieldErr.Name(), locales...); ok {
+			return message.Render(template, fieldErr.Params())
+		}
+
+		return fieldErr.Msg()
+	}
+
+	var validationErr *jsonxErr.ValidationError
+	if errors.As(err, &validationErr) {
+		if template, ok := message.Lookup(annotationKey(validationErr.Annotation), locales...); ok {
+			return message.Render(template, validationErr.TemplateParams())
+		}
+	}
+
+	return err.Error()
+}
+
+// annotationKey
+// "NotBlank" -> "@NotBlank", "pattern" stays
+func annotationKey(annotation string) string {
+	if annotation == "pattern" {
+		return annotation
+	}
+
+	return "@" + annotation
+}
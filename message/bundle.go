@@ -0,0 +1,126 @@
+package message
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultLocale
+// used when none of the requested locales has a message
+const DefaultLocale = "en"
+
+//go:embed bundles/*.json
+var builtinBundles embed.FS
+
+var bundles = map[string]map[string]string{}
+
+func init() {
+	Reset()
+}
+
+// Reset
+// drops registered messages and restores the built-in bundles
+func Reset() {
+	bundles = map[string]map[string]string{}
+
+	entries, err := builtinBundles.ReadDir("bundles")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, entry := range entries {
+		data, err := builtinBundles.ReadFile("bundles/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+		if err := RegisterJSON(strings.TrimSuffix(entry.Name(), ".json"), data); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register
+// adds messages to a locale. existing keys are overwritten.
+func Register(locale string, messages map[string]string) {
+	locale = strings.ToLower(locale)
+	bundle, ok := bundles[locale]
+	if !ok {
+		bundle = map[string]string{}
+		bundles[locale] = bundle
+	}
+
+	for key, template := range messages {
+		bundle[key] = template
+	}
+}
+
+// RegisterJSON
+// data is a flat JSON object of key to template
+func RegisterJSON(locale string, data []byte) error {
+	messages := map[string]string{}
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return errors.Join(errors.New("invalid message bundle: "+locale), err)
+	}
+
+	Register(locale, messages)
+
+	return nil
+}
+
+// LoadFile
+// registers a JSON bundle file for the locale
+func LoadFile(locale, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return RegisterJSON(locale, data)
+}
+
+// LoadDir
+// registers every <locale>.json file in dir
+func LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		locale := strings.TrimSuffix(filepath.Base(path), ".json")
+		if err := LoadFile(locale, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Lookup
+// finds the template of key for the first matching locale.
+// region tags fall back to their language ("ko-kr" -> "ko") and finally to DefaultLocale.
+func Lookup(key string, locales ...string) (string, bool) {
+	for _, locale := range candidates(locales) {
+		if template, ok := bundles[locale][key]; ok {
+			return template, true
+		}
+	}
+
+	return "", false
+}
+
+// Keys
+// every key of the locale's bundle
+func Keys(locale string) []string {
+	bundle := bundles[strings.ToLower(locale)]
+	keys := make([]string, 0, len(bundle))
+	for key := range bundle {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
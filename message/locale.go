@@ -0,0 +1,67 @@
+package message
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage
+// "ko-KR,ko;q=0.9,en;q=0.8" -> ["ko-kr", "ko", "en"]
+// tags are lower-cased and sorted by quality, "*" and q=0 are dropped
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	tags := make([]weighted, 0, 4)
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if parsed, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{tag: strings.ReplaceAll(tag, "_", "-"), q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	locales := make([]string, 0, len(tags))
+	for _, t := range tags {
+		locales = append(locales, t.tag)
+	}
+
+	return locales
+}
+
+// candidates
+// ["ko-kr", "en"] -> ["ko-kr", "ko", "en", DefaultLocale]
+func candidates(locales []string) []string {
+	result := make([]string, 0, len(locales)*2+1)
+	for _, locale := range locales {
+		locale = strings.ToLower(locale)
+		result = append(result, locale)
+		if i := strings.IndexByte(locale, '-'); i > 0 {
+			result = append(result, locale[:i])
+		}
+	}
+
+	return append(result, DefaultLocale)
+}
@@ -0,0 +1,36 @@
+package message
+
+import "strings"
+
+// Render
+// replaces {name} placeholders with params[name].
+// unknown placeholders are left as they are.
+func Render(template string, params map[string]string) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	rendered := strings.Builder{}
+	for {
+		open := strings.IndexByte(template, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(template[open:], '}')
+		if end < 0 {
+			break
+		}
+		end += open
+
+		rendered.WriteString(template[:open])
+		if value, ok := params[template[open+1:end]]; ok {
+			rendered.WriteString(value)
+		} else {
+			rendered.WriteString(template[open : end+1])
+		}
+		template = template[end+1:]
+	}
+	rendered.WriteString(template)
+
+	return rendered.String()
+}
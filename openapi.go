@@ -0,0 +1,82 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// OpenAPIVersion
+// the OpenAPI version whose schema objects OpenAPIComponents writes, 3.1 uses JSON Schema 2020-12
+const OpenAPIVersion = "3.1.0"
+
+type OpenAPIFormat int
+
+const (
+	OpenAPIJSON OpenAPIFormat = iota
+	OpenAPIYAML
+)
+
+// OpenAPIComponents
+// {"components": {"schemas": {...}}} with a schema for every type and the structs they refer to.
+// without types, the types registered by MustRegisterType are exported.
+func OpenAPIComponents(types ...reflect.Type) (map[string]any, error) {
+	if len(types) == 0 {
+		for t := range registeredTypes {
+			types = append(types, t)
+		}
+		// stable names when two packages have a type of the same name
+		sort.Slice(types, func(i, j int) bool {
+			return types[i].String() < types[j].String()
+		})
+	}
+
+	g := newSchemaGenerator("#/components/schemas/")
+	for _, t := range types {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			return nil, fmt.Errorf("%s: use only named struct type", t)
+		}
+
+		if _, err := g.refSchema(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]any{
+		"components": map[string]any{
+			"schemas": g.defs,
+		},
+	}, nil
+}
+
+// WriteOpenAPIComponents
+// writes OpenAPIComponents as JSON or YAML, ready to be merged into an OpenAPI document
+func WriteOpenAPIComponents(w io.Writer, format OpenAPIFormat, types ...reflect.Type) error {
+	components, err := OpenAPIComponents(types...)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case OpenAPIJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(components)
+	case OpenAPIYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(components); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return errors.New("unknown OpenAPI format")
+	}
+}
@@ -0,0 +1,122 @@
+package jsonx
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	fileType      = reflect.TypeOf(File{})
+	dateType      = reflect.TypeOf(Date{})
+	timeOfDayType = reflect.TypeOf(TimeOfDay{})
+)
+
+// fieldPlan
+// everything tagValidation and Check need to know about one struct field
+type fieldPlan struct {
+	field       reflect.StructField
+	annotations string
+	pattern     string
+	fieldErr    string
+	// defaultValue and doc only document the field, see Schema and OpenAPIComponents
+	defaultValue string
+	doc          string
+	// nested is the struct type validated recursively (T or *T), nil for leaf fields
+	nested reflect.Type
+}
+
+// isVisible
+// exported fields and embedded structs, whose fields are promoted by encoding/json
+func (p fieldPlan) isVisible() bool {
+	return p.field.IsExported() || (p.field.Anonymous && p.field.Type.Kind() == reflect.Struct)
+}
+
+func (p fieldPlan) hasTags() bool {
+	return p.annotations != "" || p.pattern != "" || p.fieldErr != ""
+}
+
+// isJSONIgnored
+// `json:"-"` fields are neither decoded nor encoded
+func (p fieldPlan) isJSONIgnored() bool {
+	return p.field.Tag.Get("json") == "-"
+}
+
+// jsonOption
+// reports a json tag option such as "omitempty" or "string"
+func (p fieldPlan) jsonOption(option string) bool {
+	for _, o := range strings.Split(p.field.Tag.Get("json"), ",")[1:] {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPromoted
+// fields of an embedded struct without json name are promoted to the parent
+func (p fieldPlan) isPromoted() bool {
+	return p.field.Anonymous && p.nested != nil && p.field.Tag.Get("json") == ""
+}
+
+// jsonName
+// the name encoding/json uses for the field
+func (p fieldPlan) jsonName() string {
+	name := strings.Split(p.field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return p.field.Name
+	}
+
+	return name
+}
+
+// planOf
+// walks the direct fields of a struct type.
+// the struct tags are merged with the rules of Rules and then with the loaded mapping files.
+func planOf(typeOf reflect.Type) []fieldPlan {
+	plans := make([]fieldPlan, 0, typeOf.NumField())
+	rules, mappings := codeRules[typeOf], fileRules[typeOf]
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+		plan := fieldPlan{
+			field:        field,
+			annotations:  field.Tag.Get("annotation"),
+			pattern:      field.Tag.Get("pattern"),
+			fieldErr:     field.Tag.Get("fieldErr"),
+			defaultValue: field.Tag.Get("default"),
+			doc:          field.Tag.Get("doc"),
+			nested:       nestedStructType(field.Type),
+		}
+		if rule, ok := rules[field.Name]; ok {
+			rule.apply(&plan)
+		}
+		if mapping, ok := mappings[field.Name]; ok {
+			mapping.apply(&plan)
+		}
+
+		plans = append(plans, plan)
+	}
+
+	return plans
+}
+
+// nestedStructType
+// returns the struct type to descend into, time.Time, Date, TimeOfDay, File and math/big numbers are treated as leaves
+func nestedStructType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType, dateType, timeOfDayType, fileType, bigIntType, bigFloatType, bigRatType:
+		return nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return t
+}
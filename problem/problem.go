@@ -0,0 +1,261 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/aivyss/jsonx"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"net/http"
+)
+
+// ContentType
+// media type of RFC 9457 problem details
+const ContentType = "application/problem+json"
+
+// keys of the type URI mapping besides FieldError codes
+const (
+	// TypeMalformed the body could not be decoded
+	TypeMalformed = "malformed"
+	// TypeValidation an annotation, pattern or validator rejected the body
+	TypeValidation = "validation"
+)
+
+// Details
+// RFC 9457 problem details with the "errors" extension member
+type Details struct {
+	Type     string  `json:"type"`
+	Title    string  `json:"title"`
+	Status   int     `json:"status"`
+	Detail   string  `json:"detail,omitempty"`
+	Instance string  `json:"instance,omitempty"`
+	Errors   []Issue `json:"errors,omitempty"`
+}
+
+// Issue
+// one rejected field
+type Issue struct {
+	Field      string            `json:"field,omitempty"`
+	Annotation string            `json:"annotation,omitempty"`
+	Code       string            `json:"code,omitempty"`
+	Message    string            `json:"message"`
+	Params     map[string]string `json:"params,omitempty"`
+}
+
+// Renderer
+// converts errors returned by jsonx.Unmarshal and jsonx.Validate into Details
+type Renderer struct {
+	typeURIs map[string]string
+	baseURI  string
+	titles   map[string]string
+}
+
+type Option func(r *Renderer)
+
+// WithTypeURI
+// type URI for a key: TypeMalformed, TypeValidation or the code of a registered FieldError
+func WithTypeURI(key, uri string) Option {
+	return func(r *Renderer) {
+		r.typeURIs[key] = uri
+	}
+}
+
+// WithTypeBaseURI
+// keys without WithTypeURI become base + key, e.g. "https://example.com/problems/validation".
+// without a base URI they are "about:blank".
+func WithTypeBaseURI(base string) Option {
+	return func(r *Renderer) {
+		r.baseURI = base
+	}
+}
+
+// WithTitle
+// title for a key, the HTTP status text is used otherwise
+func WithTitle(key, title string) Option {
+	return func(r *Renderer) {
+		r.titles[key] = title
+	}
+}
+
+func NewRenderer(opts ...Option) *Renderer {
+	r := &Renderer{
+		typeURIs: map[string]string{},
+		titles:   map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Default
+// used by Render and Write
+var Default = NewRenderer()
+
+func Render(err error, acceptLanguage string) *Details {
+	return Default.Render(err, acceptLanguage)
+}
+
+func Write(w http.ResponseWriter, req *http.Request, err error) {
+	Default.Write(w, req, err)
+}
+
+// Render
+// messages are localized for acceptLanguage, see jsonx.Message
+func (r *Renderer) Render(err error, acceptLanguage string) *Details {
+	if err == nil {
+		return nil
+	}
+
+	issues, fieldErrs := collect(err, acceptLanguage)
+
+	key := TypeValidation
+	status := http.StatusUnprocessableEntity
+	detail := jsonx.Message(err, acceptLanguage)
+
+	switch {
+	case errors.Is(err, jsonxErr.ErrUnmarshal):
+		key = TypeMalformed
+		status = http.StatusBadRequest
+		detail = decodeDetail(err)
+	case len(fieldErrs) > 0:
+		if _, ok := r.typeURIs[fieldErrs[0].Code()]; ok {
+			key = fieldErrs[0].Code()
+		}
+		for _, fieldErr := range fieldErrs {
+			if fieldErr.Status() != 0 {
+				status = fieldErr.Status()
+				break
+			}
+		}
+	}
+
+	if len(issues) > 1 {
+		detail = ""
+	}
+
+	return &Details{
+		Type:   r.typeURI(key),
+		Title:  r.title(key, status),
+		Status: status,
+		Detail: detail,
+		Errors: issues,
+	}
+}
+
+// Write
+// writes the problem of err with the Accept-Language of req
+func (r *Renderer) Write(w http.ResponseWriter, req *http.Request, err error) {
+	details := r.Render(err, req.Header.Get("Accept-Language"))
+	if details == nil {
+		return
+	}
+
+	WriteDetails(w, details)
+}
+
+// New
+// details of a plain HTTP status, e.g. 415 for a wrong Content-Type
+func New(status int, detail string) *Details {
+	return &Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// WriteDetails
+// writes details as application/problem+json with details.Status
+func WriteDetails(w http.ResponseWriter, details *Details) {
+	body, err := json.Marshal(details)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(details.Status)
+	_, _ = w.Write(body)
+}
+
+func (r *Renderer) typeURI(key string) string {
+	if uri, ok := r.typeURIs[key]; ok {
+		return uri
+	}
+
+	if r.baseURI == "" {
+		return "about:blank"
+	}
+
+	return r.baseURI + key
+}
+
+func (r *Renderer) title(key string, status int) string {
+	if title, ok := r.titles[key]; ok {
+		return title
+	}
+
+	return http.StatusText(status)
+}
+
+// collect
+// walks the error tree for field errors and validation errors
+func collect(err error, acceptLanguage string) ([]Issue, []*jsonxErr.FieldError) {
+	switch e := err.(type) {
+	case *jsonxErr.FieldError:
+		issue := Issue{
+			Field:   e.Field(),
+			Code:    e.Code(),
+			Message: jsonx.Message(e, acceptLanguage),
+			Params:  e.Params(),
+		}
+		var validationErr *jsonxErr.ValidationError
+		if errors.As(e, &validationErr) {
+			issue.Annotation = validationErr.Annotation
+		}
+
+		return []Issue{issue}, []*jsonxErr.FieldError{e}
+	case *jsonxErr.ValidationError:
+		return []Issue{{
+			Field:      e.Field,
+			Annotation: e.Annotation,
+			Message:    jsonx.Message(e, acceptLanguage),
+			Params:     e.Params,
+		}}, nil
+	case *jsonxErr.BindError:
+		return []Issue{{
+			Field:   e.Key,
+			Message: e.Error(),
+		}}, nil
+	case interface{ Unwrap() []error }:
+		issues := make([]Issue, 0, len(e.Unwrap()))
+		fieldErrs := make([]*jsonxErr.FieldError, 0)
+		for _, wrapped := range e.Unwrap() {
+			i, f := collect(wrapped, acceptLanguage)
+			issues = append(issues, i...)
+			fieldErrs = append(fieldErrs, f...)
+		}
+
+		return issues, fieldErrs
+	case interface{ Unwrap() error }:
+		return collect(e.Unwrap(), acceptLanguage)
+	default:
+		return nil, nil
+	}
+}
+
+// decodeDetail
+// the encoding/json error joined with errors.ErrUnmarshal
+func decodeDetail(err error) string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, wrapped := range joined.Unwrap() {
+			if wrapped != jsonxErr.ErrUnmarshal {
+				return wrapped.Error()
+			}
+		}
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,249 @@
+package jsonx
+
+import (
+	"fmt"
+	"github.com/aivyss/jsonx/tag"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var plainArgRegex = regexp.MustCompile(`^[A-Za-z0-9._/*+-]*$`)
+
+// fieldRule
+// constraints attached to a field without struct tags, by Rules or a mapping file
+type fieldRule struct {
+	annotations string
+	pattern     string
+	fieldErr    string
+	// replace drops the constraints of the weaker layers instead of merging
+	replace bool
+}
+
+// RuleBuilder
+// attaches constraints to the fields of T as if they were written in its struct tags
+type RuleBuilder[T any] struct {
+	typeOf reflect.Type
+	rule   *fieldRule
+}
+
+// Rules
+// jsonx.Rules[sdk.User]().Field("Name").NotBlank().Length(1,50).Field("Email").Email()
+// rules merge with the struct tags: an annotation replaces the tag annotation of the same name,
+// a pattern or field error replaces the one of the tag.
+func Rules[T any]() *RuleBuilder[T] {
+	typeOf := reflect.TypeOf(new(T)).Elem()
+	if typeOf.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("jsonx: Rules of %s: use only struct type", typeOf))
+	}
+
+	return &RuleBuilder[T]{typeOf: typeOf}
+}
+
+// Field
+// selects a field of T by its Go name, panics for unknown fields
+func (b *RuleBuilder[T]) Field(name string) *RuleBuilder[T] {
+	field, ok := b.typeOf.FieldByName(name)
+	if !ok || len(field.Index) != 1 {
+		panic(fmt.Sprintf("jsonx: Rules of %s: unknown field %q", b.typeOf, name))
+	}
+
+	return &RuleBuilder[T]{typeOf: b.typeOf, rule: ruleOf(codeRules, b.typeOf, name)}
+}
+
+// Annotation
+// adds an annotation written like in a tag, e.g. "@Min(1)" or a custom annotation
+func (b *RuleBuilder[T]) Annotation(annotation string) *RuleBuilder[T] {
+	if b.rule == nil {
+		panic(fmt.Sprintf("jsonx: Rules of %s: call Field before adding constraints", b.typeOf))
+	}
+
+	b.rule.annotations = mergeAnnotations(b.rule.annotations, "@"+strings.TrimPrefix(strings.TrimSpace(annotation), "@"))
+	return b
+}
+
+// Pattern
+// same as the pattern tag
+func (b *RuleBuilder[T]) Pattern(pattern string) *RuleBuilder[T] {
+	if b.rule == nil {
+		panic(fmt.Sprintf("jsonx: Rules of %s: call Field before adding constraints", b.typeOf))
+	}
+
+	b.rule.pattern = pattern
+	return b
+}
+
+// FieldError
+// the field error of every failure of the field without mapping, same as `fieldErr:"name"`
+func (b *RuleBuilder[T]) FieldError(name string) *RuleBuilder[T] {
+	return b.fieldErrEntry(name)
+}
+
+// FieldErrorFor
+// the field error of one annotation or "pattern", same as `fieldErr:"Length=name"`
+func (b *RuleBuilder[T]) FieldErrorFor(annotation, name string) *RuleBuilder[T] {
+	return b.fieldErrEntry(strings.TrimPrefix(annotation, "@") + "=" + name)
+}
+
+func (b *RuleBuilder[T]) fieldErrEntry(entry string) *RuleBuilder[T] {
+	if b.rule == nil {
+		panic(fmt.Sprintf("jsonx: Rules of %s: call Field before adding constraints", b.typeOf))
+	}
+
+	if b.rule.fieldErr == "" {
+		b.rule.fieldErr = entry
+	} else {
+		b.rule.fieldErr += "," + entry
+	}
+
+	return b
+}
+
+func (b *RuleBuilder[T]) NotBlank() *RuleBuilder[T]         { return b.Annotation("NotBlank") }
+func (b *RuleBuilder[T]) NotEmpty() *RuleBuilder[T]         { return b.Annotation("NotEmpty") }
+func (b *RuleBuilder[T]) Required() *RuleBuilder[T]         { return b.Annotation("Required") }
+func (b *RuleBuilder[T]) NotContainsNil() *RuleBuilder[T]   { return b.Annotation("NotContainsNil") }
+func (b *RuleBuilder[T]) NotContainsEmpty() *RuleBuilder[T] { return b.Annotation("NotContainsEmpty") }
+func (b *RuleBuilder[T]) NotContainsBlank() *RuleBuilder[T] { return b.Annotation("NotContainsBlank") }
+func (b *RuleBuilder[T]) Positive() *RuleBuilder[T]         { return b.Annotation("Positive") }
+func (b *RuleBuilder[T]) Negative() *RuleBuilder[T]         { return b.Annotation("Negative") }
+func (b *RuleBuilder[T]) PositiveOrZero() *RuleBuilder[T]   { return b.Annotation("PositiveOrZero") }
+func (b *RuleBuilder[T]) NegativeOrZero() *RuleBuilder[T]   { return b.Annotation("NegativeOrZero") }
+func (b *RuleBuilder[T]) Future() *RuleBuilder[T]           { return b.Annotation("Future") }
+func (b *RuleBuilder[T]) Present() *RuleBuilder[T]          { return b.Annotation("Present") }
+func (b *RuleBuilder[T]) Past() *RuleBuilder[T]             { return b.Annotation("Past") }
+func (b *RuleBuilder[T]) FutureOrPresent() *RuleBuilder[T]  { return b.Annotation("FutureOrPresent") }
+func (b *RuleBuilder[T]) PastOrPresent() *RuleBuilder[T]    { return b.Annotation("PastOrPresent") }
+
+// Min
+// the bound as written in @Min, e.g. "0", "123456789012345678901234567890", "1m30s" or "2024-01-01"
+func (b *RuleBuilder[T]) Min(minValue string) *RuleBuilder[T] {
+	return b.call("Min", minValue)
+}
+
+// Max
+// the bound as written in @Max, see Min
+func (b *RuleBuilder[T]) Max(maxValue string) *RuleBuilder[T] {
+	return b.call("Max", maxValue)
+}
+
+func (b *RuleBuilder[T]) Length(minValue, maxValue int) *RuleBuilder[T] {
+	return b.call("Length", strconv.Itoa(minValue), strconv.Itoa(maxValue))
+}
+
+// Email
+// options such as "strict", "allowIDN", "maxLength=100" or "allow=example.com"
+func (b *RuleBuilder[T]) Email(options ...string) *RuleBuilder[T] {
+	if len(options) == 0 {
+		return b.Annotation("Email")
+	}
+
+	return b.call("Email", options...)
+}
+
+// MaxBytes
+// size such as "5MB"
+func (b *RuleBuilder[T]) MaxBytes(size string) *RuleBuilder[T] {
+	return b.call("MaxBytes", size)
+}
+
+func (b *RuleBuilder[T]) ContentType(mediaTypes ...string) *RuleBuilder[T] {
+	return b.call("ContentType", mediaTypes...)
+}
+
+func (b *RuleBuilder[T]) Enum(values ...string) *RuleBuilder[T] {
+	return b.call("Enum", values...)
+}
+
+// call
+// adds the annotation with its arguments, panics for arguments with a single quote,
+// which the annotation syntax can not escape
+func (b *RuleBuilder[T]) call(name string, args ...string) *RuleBuilder[T] {
+	for _, arg := range args {
+		if strings.Contains(arg, "'") {
+			panic(fmt.Sprintf("jsonx: Rules of %s: @%s argument %q contains a single quote", b.typeOf, name, arg))
+		}
+	}
+
+	return b.Annotation(annotationCall(name, args...))
+}
+
+// ruleOf
+// the rule of a field in rules, created on first use
+func ruleOf(rules map[reflect.Type]map[string]*fieldRule, typeOf reflect.Type, fieldName string) *fieldRule {
+	fields, ok := rules[typeOf]
+	if !ok {
+		fields = map[string]*fieldRule{}
+		rules[typeOf] = fields
+	}
+
+	rule, ok := fields[fieldName]
+	if !ok {
+		rule = &fieldRule{}
+		fields[fieldName] = rule
+	}
+
+	return rule
+}
+
+// apply
+// merges the rule into a field plan
+func (r *fieldRule) apply(plan *fieldPlan) {
+	if r.replace {
+		plan.annotations, plan.pattern, plan.fieldErr = "", "", ""
+	}
+
+	plan.annotations = mergeAnnotations(plan.annotations, r.annotations)
+	if r.pattern != "" {
+		plan.pattern = r.pattern
+	}
+	if r.fieldErr != "" {
+		plan.fieldErr = r.fieldErr
+	}
+}
+
+// mergeAnnotations
+// "@NotBlank @Length(1,50)" + "@Length(1,100)" -> "@NotBlank @Length(1,100)"
+func mergeAnnotations(base, overrides string) string {
+	if overrides == "" {
+		return base
+	}
+
+	replaced := map[string]bool{}
+	for _, annoStr := range tag.SplitAnnotationTag(overrides) {
+		replaced[annotationName(annoStr)] = true
+	}
+
+	merged := make([]string, 0)
+	for _, annoStr := range tag.SplitAnnotationTag(base) {
+		if !replaced[annotationName(annoStr)] {
+			merged = append(merged, "@"+annoStr)
+		}
+	}
+	for _, annoStr := range tag.SplitAnnotationTag(overrides) {
+		merged = append(merged, "@"+annoStr)
+	}
+
+	return strings.Join(merged, " ")
+}
+
+func annotationName(annoStr string) string {
+	name, _, _ := strings.Cut(annoStr, "(")
+	return strings.TrimSpace(name)
+}
+
+// annotationCall
+// "Enum", ["a", "on hold"] -> "Enum(a,'on hold')"
+func annotationCall(name string, args ...string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if plainArgRegex.MatchString(arg) {
+			quoted = append(quoted, arg)
+		} else {
+			quoted = append(quoted, "'"+arg+"'")
+		}
+	}
+
+	return name + "(" + strings.Join(quoted, ",") + ")"
+}
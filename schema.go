@@ -0,0 +1,790 @@
+package jsonx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/tag"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaDialect
+// $schema of the documents made by Schema
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// SchemaHook
+// returns the schema keywords contributed by an annotation, e.g. {"format": "uuid"}
+type SchemaHook func(args []string) map[string]any
+
+var (
+	// formatPatterns
+	// the grammars of the format annotations without a JSON Schema format
+	formatPatterns = map[string]string{
+		"ULID":        `^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`,
+		"Hex":         `^[0-9A-Fa-f]+$`,
+		"Base64":      `^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/][AQgw]==|[A-Za-z0-9+/]{2}[AEIMQUYcgkosw048]=)?$`,
+		"Base64(url)": `^(?:[A-Za-z0-9_-]{4})*(?:[A-Za-z0-9_-][AQgw](?:==)?|[A-Za-z0-9_-]{2}[AEIMQUYcgkosw048]=?)?$`,
+		"Semver": `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+			`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+			`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`,
+		"Hostname": `^[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`,
+		"CIDR":     `^(?:[0-9]{1,3}(?:\.[0-9]{1,3}){3}/[0-9]{1,2}|[0-9A-Fa-f:.]*:[0-9A-Fa-f:.]*/[0-9]{1,3})$`,
+		"MAC": `^(?:[0-9A-Fa-f]{2}(?::[0-9A-Fa-f]{2}){5}(?:(?::[0-9A-Fa-f]{2}){2})?` +
+			`|[0-9A-Fa-f]{2}(?:-[0-9A-Fa-f]{2}){5}(?:(?:-[0-9A-Fa-f]{2}){2})?` +
+			`|[0-9A-Fa-f]{4}(?:\.[0-9A-Fa-f]{4}){2}(?:\.[0-9A-Fa-f]{4})?)$`,
+		"Port": `^(?:[1-9][0-9]{0,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5])$`,
+	}
+	durationType     = reflect.TypeOf(time.Duration(0))
+	textDurationType = reflect.TypeOf(Duration(0))
+	jsonNumberType   = reflect.TypeOf(json.Number(""))
+	bigIntType       = reflect.TypeOf(big.Int{})
+	bigFloatType     = reflect.TypeOf(big.Float{})
+	bigRatType       = reflect.TypeOf(big.Rat{})
+	rawMessageType   = reflect.TypeOf(json.RawMessage(nil))
+	notBlankRegex    = `\S`
+	// timeOfDayRegex 15:04, 15:04:05 or 15:04:05.999999999
+	timeOfDayRegex = `^(?:[01][0-9]|2[0-3]):[0-5][0-9](?::[0-5][0-9](?:\.[0-9]{1,9})?)?$`
+	// durationRegex the strings time.ParseDuration accepts, such as 1h30m or -1.5s
+	durationRegex = `^[-+]?(?:0|(?:(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:ns|us|µs|μs|ms|s|m|h))+)$`
+)
+
+// RegisterSchemaHook
+// lets a custom annotation contribute schema keywords to the fields it is used on
+func RegisterSchemaHook(annotationName string, hook SchemaHook) {
+	schemaHooks[annotationName] = hook
+}
+
+// Schema
+// JSON Schema (draft 2020-12) of T, derived from json names and the annotation and pattern tags.
+// nested named structs are put in $defs.
+func Schema[T any]() (map[string]any, error) {
+	typeOf := reflect.TypeOf(new(T)).Elem()
+	if typeOf.Kind() != reflect.Struct {
+		return nil, errors.New("use only struct type")
+	}
+
+	g := newSchemaGenerator("#/$defs/")
+	root, err := g.structSchema(typeOf)
+	if err != nil {
+		return nil, err
+	}
+
+	root["$schema"] = SchemaDialect
+	if len(g.defs) > 0 {
+		root["$defs"] = g.defs
+	}
+
+	return root, nil
+}
+
+// schemaGenerator
+// refPrefix is "#/$defs/" for JSON Schema and "#/components/schemas/" for OpenAPI
+type schemaGenerator struct {
+	refPrefix string
+	defs      map[string]any
+	names     map[reflect.Type]string
+}
+
+func newSchemaGenerator(refPrefix string) *schemaGenerator {
+	return &schemaGenerator{
+		refPrefix: refPrefix,
+		defs:      map[string]any{},
+		names:     map[reflect.Type]string{},
+	}
+}
+
+// structSchema
+// object schema of a struct type, embedded structs are flattened like encoding/json does
+func (g *schemaGenerator) structSchema(typeOf reflect.Type) (map[string]any, error) {
+	properties := map[string]any{}
+	required := make([]string, 0)
+
+	if err := g.addProperties(typeOf, properties, &required); err != nil {
+		return nil, err
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+
+	return schema, nil
+}
+
+func (g *schemaGenerator) addProperties(typeOf reflect.Type, properties map[string]any, required *[]string) error {
+	for _, plan := range planOf(typeOf) {
+		if !plan.isVisible() || plan.isJSONIgnored() {
+			continue
+		}
+
+		if plan.isPromoted() {
+			if err := g.addProperties(plan.nested, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+
+		property, isRequired, err := g.fieldSchema(plan)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", typeOf, plan.field.Name, err)
+		}
+
+		properties[plan.jsonName()] = property
+		if isRequired {
+			*required = append(*required, plan.jsonName())
+		}
+	}
+
+	return nil
+}
+
+// fieldSchema
+// the schema of the field type with the keywords of its annotations and pattern
+func (g *schemaGenerator) fieldSchema(plan fieldPlan) (map[string]any, bool, error) {
+	schema, err := g.typeSchema(plan.field.Type)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if plan.jsonOption("string") && isScalarKind(elemType(plan.field.Type).Kind()) {
+		schema = map[string]any{"type": nullable("string", plan.field.Type)}
+	}
+
+	isRequired := false
+	annotations := make([]*definitions.Annotation, 0)
+	for _, annoStr := range tag.SplitAnnotationTag(plan.annotations) {
+		annotation, err := definitions.ConvertToAnnotation(annoStr)
+		if err != nil {
+			return nil, false, err
+		}
+		annotations = append(annotations, annotation)
+
+		switch annotation.Name() {
+		case "Required", "NotEmpty", "NotBlank":
+			isRequired = true
+		}
+
+		schema = g.applyAnnotation(schema, plan.field.Type, annotation)
+		if hook, ok := schemaHooks[annotation.Name()]; ok {
+			schema = mergeSchema(schema, hook(annotation.Args()))
+		}
+	}
+	if rejectsNil(plan.field.Type, annotations) {
+		// @NotBlank, @Positive, ... fail on nil like @Required
+		schema = withoutNull(schema)
+	}
+
+	if plan.pattern != "" {
+		schema = mergeSchema(schema, map[string]any{"pattern": plan.pattern})
+	}
+
+	if plan.doc != "" {
+		schema = withKeyword(schema, "description", plan.doc)
+	}
+	if plan.defaultValue != "" {
+		defaultValue, err := defaultValueOf(plan.field.Type, plan.defaultValue)
+		if err != nil {
+			return nil, false, err
+		}
+		schema = withKeyword(schema, "default", defaultValue)
+	}
+
+	return schema, isRequired, nil
+}
+
+// applyAnnotation
+// maps built-in annotations to JSON Schema keywords
+func (g *schemaGenerator) applyAnnotation(schema map[string]any, t reflect.Type, annotation *definitions.Annotation) map[string]any {
+	params := annotation.Params()
+
+	if annotation.Name() == "Port" && isIntegerKind(elemType(t).Kind()) {
+		return mergeSchema(schema, map[string]any{"minimum": 1, "maximum": 65535})
+	}
+	if isFormatAnnotation(annotation.Name()) {
+		keywords := formatKeywords(annotation)
+		if elemType(t).Kind() == reflect.String {
+			return mergeSchema(schema, keywords)
+		}
+		return withItems(schema, func(items map[string]any) map[string]any {
+			return mergeSchema(items, keywords)
+		})
+	}
+
+	if isNumericAnnotation(annotation.Name()) && !isJSONNumber(t) {
+		// dates, times of day and numbers encoded as strings
+		return schema
+	}
+
+	switch annotation.Name() {
+	case "NotBlank":
+		return mergeSchema(schema, map[string]any{"minLength": 1, "pattern": notBlankRegex})
+	case "NotEmpty":
+		return mergeSchema(schema, map[string]any{"minLength": 1})
+	case "Required":
+		return withoutNull(schema)
+	case "Positive":
+		return mergeSchema(schema, map[string]any{"exclusiveMinimum": 0})
+	case "Negative":
+		return mergeSchema(schema, map[string]any{"exclusiveMaximum": 0})
+	case "PositiveOrZero":
+		return mergeSchema(schema, map[string]any{"minimum": 0})
+	case "NegativeOrZero":
+		return mergeSchema(schema, map[string]any{"maximum": 0})
+	case "Future", "Past", "Present", "FutureOrPresent", "PastOrPresent",
+		"PastWithin", "FutureAfter", "After", "Before", "Between":
+		if elemType(t).Kind() == reflect.String {
+			return mergeSchema(schema, layoutKeywords(params["layout"]))
+		}
+		return schema
+	case "Min":
+		if n, ok := boundNumber(t, params["min"]); ok {
+			return mergeSchema(schema, map[string]any{"minimum": n})
+		}
+		return schema
+	case "Max":
+		if n, ok := boundNumber(t, params["max"]); ok {
+			return mergeSchema(schema, map[string]any{"maximum": n})
+		}
+		return schema
+	case "Digits", "Scale", "MultipleOf", "Currency":
+		if step, ok := decimalStep(annotation); ok {
+			return mergeSchema(schema, map[string]any{"multipleOf": schemaNumber(step)})
+		}
+		return schema
+	case "Length":
+		minKey, maxKey := lengthKeywords(elemType(t))
+		return mergeSchema(schema, map[string]any{
+			minKey: schemaNumber(params["min"]),
+			maxKey: schemaNumber(params["max"]),
+		})
+	case "Enum":
+		values := make([]any, 0, len(annotation.Args()))
+		for _, arg := range annotation.Args() {
+			if isScalarKind(elemType(t).Kind()) || isJSONNumber(t) {
+				values = append(values, schemaNumber(arg))
+			} else {
+				values = append(values, arg)
+			}
+		}
+		return mergeSchema(schema, map[string]any{"enum": values})
+	case "NotContainsNil":
+		return withItems(schema, withoutNull)
+	case "NotContainsEmpty":
+		return withItems(schema, func(items map[string]any) map[string]any {
+			return mergeSchema(withoutNull(items), map[string]any{"minLength": 1})
+		})
+	case "NotContainsBlank":
+		return withItems(schema, func(items map[string]any) map[string]any {
+			return mergeSchema(withoutNull(items), map[string]any{"minLength": 1, "pattern": notBlankRegex})
+		})
+	}
+
+	return schema
+}
+
+// typeSchema
+// the schema of a Go type as encoding/json encodes it
+func (g *schemaGenerator) typeSchema(t reflect.Type) (map[string]any, error) {
+	if t.Kind() == reflect.Pointer {
+		schema, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return withNull(schema), nil
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case t == durationType:
+		return map[string]any{"type": "integer"}, nil
+	case t == dateType:
+		// the zero Date encodes as null
+		return map[string]any{"type": []any{"string", "null"}, "format": "date"}, nil
+	case t == timeOfDayType:
+		return map[string]any{"type": "string", "pattern": timeOfDayRegex}, nil
+	case t == textDurationType:
+		return map[string]any{"type": "string", "pattern": durationRegex}, nil
+	case t == jsonNumberType:
+		return map[string]any{"type": "number"}, nil
+	case t == bigIntType:
+		return map[string]any{"type": "integer"}, nil
+	case t == bigFloatType, t == bigRatType:
+		return map[string]any{"type": "string"}, nil
+	case t == rawMessageType:
+		return map[string]any{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		}
+
+		items, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]any{"type": "array", "items": items}
+		if t.Kind() == reflect.Array {
+			schema["minItems"] = t.Len()
+			schema["maxItems"] = t.Len()
+		}
+		if t.Kind() == reflect.Slice {
+			schema["type"] = []any{"array", "null"}
+		}
+
+		return schema, nil
+	case reflect.Map:
+		values, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{"type": []any{"object", "null"}, "additionalProperties": values}, nil
+	case reflect.Struct:
+		return g.refSchema(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// refSchema
+// named structs go to the definitions, anonymous ones are inlined
+func (g *schemaGenerator) refSchema(t reflect.Type) (map[string]any, error) {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+
+	if name, ok := g.names[t]; ok {
+		return map[string]any{"$ref": g.refPrefix + name}, nil
+	}
+
+	name := g.defName(t)
+	g.names[t] = name
+	g.defs[name] = map[string]any{}
+
+	schema, err := g.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	g.defs[name] = schema
+
+	return map[string]any{"$ref": g.refPrefix + name}, nil
+}
+
+// defName
+// the type name, suffixed with a number when another package has the same name
+func (g *schemaGenerator) defName(t reflect.Type) string {
+	name := t.Name()
+	for i := 2; ; i++ {
+		if _, taken := g.defs[name]; !taken {
+			return name
+		}
+		name = t.Name() + strconv.Itoa(i)
+	}
+}
+
+// isFormatAnnotation
+// annotations checking the grammar of strings, also element-wise
+func isFormatAnnotation(name string) bool {
+	switch name {
+	case "Email", "UUID", "ULID", "Hex", "Base64", "Semver",
+		"URL", "URI", "Hostname", "IP", "IPv4", "IPv6", "CIDR", "MAC", "Port",
+		"DateTime", "Date", "RFC3339", "Timezone":
+		return true
+	default:
+		return false
+	}
+}
+
+// formatPattern
+// the pattern of a format annotation, "" when it has a JSON Schema format
+func formatPattern(annotation *definitions.Annotation) string {
+	if annotation.Name() == "Base64" && annotation.Params()["encoding"] == "url" {
+		return formatPatterns["Base64(url)"]
+	}
+
+	return formatPatterns[annotation.Name()]
+}
+
+// formatKeywords
+// JSON Schema keywords of a format annotation
+func formatKeywords(annotation *definitions.Annotation) map[string]any {
+	switch annotation.Name() {
+	case "Email":
+		return emailKeywords(annotation)
+	case "UUID":
+		return map[string]any{"format": "uuid"}
+	case "URL":
+		if pattern := schemePattern(annotation); pattern != "" {
+			return map[string]any{"format": "uri", "pattern": pattern}
+		}
+		return map[string]any{"format": "uri"}
+	case "URI":
+		return map[string]any{"format": "uri"}
+	case "Hostname":
+		return map[string]any{"format": "hostname", "maxLength": 253}
+	case "IP":
+		return map[string]any{"oneOf": []any{map[string]any{"format": "ipv4"}, map[string]any{"format": "ipv6"}}}
+	case "IPv4":
+		return map[string]any{"format": "ipv4"}
+	case "IPv6":
+		return map[string]any{"format": "ipv6"}
+	case "DateTime":
+		return layoutKeywords(annotation.Params()["layout"])
+	case "Date":
+		return map[string]any{"format": "date"}
+	case "RFC3339":
+		return map[string]any{"format": "date-time"}
+	case "Timezone":
+		return nil
+	case "Hex", "Base64":
+		return map[string]any{"minLength": 1, "pattern": formatPattern(annotation)}
+	default:
+		return map[string]any{"pattern": formatPattern(annotation)}
+	}
+}
+
+// layoutKeywords
+// the JSON Schema format of a layout argument, nil for layouts without format
+func layoutKeywords(layout string) map[string]any {
+	switch layout {
+	case "RFC3339", "RFC3339Nano", time.RFC3339, time.RFC3339Nano:
+		return map[string]any{"format": "date-time"}
+	case "DateOnly", "ISO8601Date", time.DateOnly:
+		return map[string]any{"format": "date"}
+	default:
+		return nil
+	}
+}
+
+// emailKeywords
+// idn-email with allowIDN, the default RFC 5321 limit is implied by the format
+func emailKeywords(annotation *definitions.Annotation) map[string]any {
+	keywords := map[string]any{"format": "email"}
+	for _, arg := range annotation.Args() {
+		if arg == "allowIDN" {
+			keywords["format"] = "idn-email"
+		}
+	}
+	if maxLength := emailMaxLength(annotation); maxLength > 0 {
+		keywords["maxLength"] = maxLength
+	}
+
+	return keywords
+}
+
+// emailMaxLength
+// the maxLength argument of @Email, 0 without
+func emailMaxLength(annotation *definitions.Annotation) int {
+	for _, arg := range annotation.Args() {
+		if value, ok := strings.CutPrefix(arg, "maxLength="); ok {
+			maxLength, _ := strconv.Atoi(value)
+			return maxLength
+		}
+	}
+
+	return 0
+}
+
+// schemePattern
+// the case-insensitive scheme allowlist of @URL(http,https), "" without arguments
+func schemePattern(annotation *definitions.Annotation) string {
+	schemes := annotation.Params()["schemes"]
+	if schemes == "" {
+		return ""
+	}
+
+	alternatives := make([]string, 0, strings.Count(schemes, ",")+1)
+	for _, scheme := range strings.Split(schemes, ", ") {
+		var b strings.Builder
+		for _, c := range scheme {
+			if lower, upper := strings.ToLower(string(c)), strings.ToUpper(string(c)); lower != upper {
+				b.WriteString("[" + upper + lower + "]")
+			} else {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		}
+		alternatives = append(alternatives, b.String())
+	}
+
+	return "^(?:" + strings.Join(alternatives, "|") + "):"
+}
+
+// defaultValueOf
+// the `default` tag decoded into the field type and encoded back as JSON.
+// strings, time.Time, Date, TimeOfDay and Duration are written without quotes: `default:"draft"`, `default:"10"`, `default:"[1,2]"`.
+func defaultValueOf(t reflect.Type, raw string) (any, error) {
+	if elem := elemType(t); elem.Kind() == reflect.String || elem == timeType || elem == dateType || elem == timeOfDayType || elem == textDurationType {
+		raw = strconv.Quote(raw)
+	}
+
+	value := reflect.New(t)
+	if err := json.Unmarshal([]byte(raw), value.Interface()); err != nil {
+		return nil, fmt.Errorf("wrong default value: %w", err)
+	}
+
+	encoded, err := json.Marshal(value.Elem().Interface())
+	if err != nil {
+		return nil, fmt.Errorf("wrong default value: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("wrong default value: %w", err)
+	}
+
+	return plainNumbers(generic), nil
+}
+
+// plainNumbers
+// json.Number to int64 or float64, so that every encoder writes numbers
+func plainNumbers(v any) any {
+	switch value := v.(type) {
+	case json.Number:
+		return schemaNumber(value.String())
+	case []any:
+		for i, elem := range value {
+			value[i] = plainNumbers(elem)
+		}
+	case map[string]any:
+		for k, elem := range value {
+			value[k] = plainNumbers(elem)
+		}
+	}
+
+	return v
+}
+
+// withKeyword
+// sets an annotation keyword such as description on the property itself, not on a nullable branch
+func withKeyword(schema map[string]any, keyword string, value any) map[string]any {
+	merged := make(map[string]any, len(schema)+1)
+	for k, v := range schema {
+		merged[k] = v
+	}
+	merged[keyword] = value
+
+	return merged
+}
+
+func mergeSchema(schema map[string]any, keywords map[string]any) map[string]any {
+	if ref, ok := schema["anyOf"].([]any); ok && len(keywords) > 0 {
+		// nullable reference: the keywords apply to the non-null branch
+		merged := make([]any, 0, len(ref))
+		for _, branch := range ref {
+			b := branch.(map[string]any)
+			if b["type"] != "null" {
+				b = mergeSchema(b, keywords)
+			}
+			merged = append(merged, b)
+		}
+
+		return map[string]any{"anyOf": merged}
+	}
+
+	merged := make(map[string]any, len(schema)+len(keywords))
+	for k, v := range schema {
+		merged[k] = v
+	}
+	for k, v := range keywords {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// withNull
+// allows null, used for pointers
+func withNull(schema map[string]any) map[string]any {
+	if _, ok := schema["$ref"]; ok {
+		return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+	}
+
+	switch typ := schema["type"].(type) {
+	case string:
+		return mergeSchema(schema, map[string]any{"type": []any{typ, "null"}})
+	default:
+		return schema
+	}
+}
+
+// withoutNull
+// reverts withNull and the nullable types of slices and maps
+func withoutNull(schema map[string]any) map[string]any {
+	if branches, ok := schema["anyOf"].([]any); ok && len(branches) == 2 {
+		if b, ok := branches[1].(map[string]any); ok && b["type"] == "null" {
+			return branches[0].(map[string]any)
+		}
+	}
+
+	types, ok := schema["type"].([]any)
+	if !ok || len(types) != 2 || types[1] != "null" {
+		return schema
+	}
+
+	return mergeSchema(schema, map[string]any{"type": types[0]})
+}
+
+func withItems(schema map[string]any, apply func(items map[string]any) map[string]any) map[string]any {
+	items, ok := schema["items"].(map[string]any)
+	if !ok {
+		return schema
+	}
+
+	return mergeSchema(schema, map[string]any{"items": apply(items)})
+}
+
+func nullable(typ string, t reflect.Type) any {
+	if t.Kind() == reflect.Pointer {
+		return []any{typ, "null"}
+	}
+
+	return typ
+}
+
+func lengthKeywords(t reflect.Type) (string, string) {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "minItems", "maxItems"
+	case reflect.Map:
+		return "minProperties", "maxProperties"
+	default:
+		return "minLength", "maxLength"
+	}
+}
+
+// decimalStep
+// the multipleOf of a decimal annotation, 1e-2 for @Scale(2), @Digits(5,2) and @Currency(USD).
+// integer digits of @Digits are checked on the server only.
+func decimalStep(annotation *definitions.Annotation) (string, bool) {
+	params := annotation.Params()
+
+	switch annotation.Name() {
+	case "MultipleOf":
+		return params["multipleOf"], true
+	case "Scale":
+		return "1e-" + params["scale"], true
+	case "Digits":
+		return "1e-" + params["fraction"], true
+	case "Currency":
+		// codes such as XAU have no minor unit
+		return "1e-" + params["scale"], params["scale"] != ""
+	default:
+		return "", false
+	}
+}
+
+// schemaNumber
+// annotation arguments as JSON numbers
+func schemaNumber(arg string) any {
+	if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(arg, 64); err == nil {
+		// numbers float64 can not hold, such as 2^53+1, are written as they are
+		shortest, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+		if r, ok := new(big.Rat).SetString(arg); ok && shortest.Cmp(r) != 0 {
+			return json.Number(arg)
+		}
+		return f
+	}
+
+	return arg
+}
+
+// boundNumber
+// the JSON number of a @Min or @Max argument, nanoseconds for time.Duration.
+// dates, times of day and Duration are strings in JSON and have no bound keyword.
+func boundNumber(t reflect.Type, arg string) (any, bool) {
+	if elemType(t) == durationType {
+		d, err := time.ParseDuration(arg)
+		return d.Nanoseconds(), err == nil
+	}
+	if _, err := strconv.ParseFloat(arg, 64); err != nil {
+		return nil, false
+	}
+
+	return schemaNumber(arg), true
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isJSONNumber
+// types encoded as JSON numbers, big.Float, big.Rat and Duration are encoded as strings
+func isJSONNumber(t reflect.Type) bool {
+	switch elem := elemType(t); {
+	case elem == jsonNumberType, elem == bigIntType:
+		return true
+	case elem == textDurationType:
+		return false
+	default:
+		return isScalarKind(elem.Kind()) && elem.Kind() != reflect.Bool
+	}
+}
+
+// isNumericAnnotation
+// annotations mapped to minimum, maximum and multipleOf keywords
+func isNumericAnnotation(name string) bool {
+	switch name {
+	case "Positive", "Negative", "PositiveOrZero", "NegativeOrZero", "Min", "Max",
+		"Digits", "Scale", "MultipleOf", "Currency":
+		return true
+	default:
+		return false
+	}
+}
+
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -3,21 +3,63 @@ package tag
 import (
 	"errors"
 	"github.com/aivyss/jsonx/definitions"
+	jsonxErr "github.com/aivyss/jsonx/errors"
 	"regexp"
 	"strings"
 )
 
-func ValidateAnnotationTag(tagValue string, value any) error {
-	annotations := strings.Split(strings.TrimSpace(tagValue), "@")[1:]
+// SplitAnnotationTag
+// "@NotBlank @Length(1, 50)" -> ["NotBlank", "Length(1, 50)"]
+// '@' inside arguments does not start a new annotation
+func SplitAnnotationTag(tagValue string) []string {
+	annotations := make([]string, 0, 2)
+	current := strings.Builder{}
+	depth := 0
+	quoted := false
+	started := false
+
+	for _, r := range strings.TrimSpace(tagValue) {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == '(' && !quoted:
+			depth++
+		case r == ')' && !quoted:
+			depth--
+		case r == '@' && !quoted && depth == 0:
+			if started {
+				annotations = append(annotations, strings.TrimSpace(current.String()))
+			}
+			current.Reset()
+			started = true
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	if started {
+		annotations = append(annotations, strings.TrimSpace(current.String()))
+	}
+
+	return annotations
+}
 
-	for _, annoStr := range annotations {
+func ValidateAnnotationTag(tagValue string, value any) error {
+	for _, annoStr := range SplitAnnotationTag(tagValue) {
 		annotation, err := definitions.ConvertToAnnotation(annoStr)
 		if err != nil {
 			return err
 		}
 
 		if err := annotation.Validate(value); err != nil {
-			return err
+			// the rejected element of a slice, array or map field
+			var elementErr *jsonxErr.ElementError
+			if errors.As(err, &elementErr) {
+				value = elementErr.Value
+			}
+
+			return jsonxErr.NewValidationErr(annotation.Name(), annotation.Params(), value, err)
 		}
 	}
 
@@ -41,7 +83,12 @@ func RegexTag(pattern string, value any) error {
 	}
 
 	if matched := regex.Match([]byte(s)); !matched {
-		return errors.New("not matched (pattern)")
+		return jsonxErr.NewValidationErr(
+			"pattern",
+			map[string]string{"pattern": pattern},
+			value,
+			errors.New("not matched (pattern)"),
+		)
 	}
 
 	return nil
@@ -1,60 +1,91 @@
 package jsonx
 
 import (
+	"errors"
+	jsonxErr "github.com/aivyss/jsonx/errors"
 	"github.com/aivyss/jsonx/tag"
 	"reflect"
-	"time"
 )
 
 func tagValidation[V any](v V) error {
-	typeOf := reflect.TypeOf(v)
-
-	for i := 0; i < typeOf.NumField(); i++ {
-		kind := typeOf.Field(i).Type.Kind()
-
-		switch kind {
-		case reflect.Pointer:
-			if typeOf.Field(i).Type.Elem().Kind() == reflect.Struct {
-				if typeOf.Field(i).Type.Elem() != reflect.TypeOf(time.Time{}) {
-					if err := tagValidation(reflect.ValueOf(v).Field(i).Interface()); err != nil {
-						return err
-					}
-				}
+	return structValidation(reflect.ValueOf(v), "")
+}
 
-				return nil
-			}
-		case reflect.Struct:
-			if typeOf.Field(i).Type != reflect.TypeOf(time.Time{}) {
-				if err := tagValidation(reflect.ValueOf(v).Field(i).Interface()); err != nil {
-					return err
-				}
+// structValidation
+// path is the JSON path of the struct, "" for the root.
+// annotations of a nested struct field such as `@Required` on *Address run before its fields,
+// and the fields after a nested struct are validated as well.
+func structValidation(valueOf reflect.Value, path string) error {
+	for _, plan := range planOf(valueOf.Type()) {
+		if !plan.isVisible() {
+			continue
+		}
 
-				return nil
-			}
+		fieldValue := valueOf.FieldByIndex(plan.field.Index)
+		fieldPath := joinPath(path, plan.jsonName())
+		if plan.field.Anonymous && plan.field.Tag.Get("json") == "" {
+			// promoted fields keep the path of the embedding struct
+			fieldPath = path
 		}
 
 		// annotation validation
-		fieldTag := typeOf.Field(i).Tag
-
-		if annotations := fieldTag.Get("annotation"); annotations != "" {
+		if plan.annotations != "" && plan.field.IsExported() {
 			if err := tag.ValidateAnnotationTag(
-				annotations,
-				reflect.ValueOf(v).Field(i).Interface(),
+				plan.annotations,
+				fieldValue.Interface(),
 			); err != nil {
-				return exchangeIfFieldError(fieldTag, err)
+				return exchangeIfFieldError(plan.fieldErr, withField(err, fieldPath))
 			}
 		}
 
 		// regex validation
-		if pattern := fieldTag.Get("pattern"); pattern != "" {
+		if plan.pattern != "" && plan.field.IsExported() {
 			if err := tag.RegexTag(
-				pattern,
-				reflect.ValueOf(v).Field(i).Interface(),
+				plan.pattern,
+				fieldValue.Interface(),
 			); err != nil {
-				return exchangeIfFieldError(fieldTag, err)
+				return exchangeIfFieldError(plan.fieldErr, withField(err, fieldPath))
+			}
+		}
+
+		// nested struct validation
+		if plan.nested != nil {
+			if fieldValue.Kind() == reflect.Pointer {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+			}
+
+			if err := structValidation(fieldValue, fieldPath); err != nil {
+				return err
 			}
 		}
 	}
 
 	return nil
 }
+
+// withField
+// sets the field path of a *errors.ValidationError, "tags[0]" when an element was rejected
+func withField(err error, path string) error {
+	var validationErr *jsonxErr.ValidationError
+	if errors.As(err, &validationErr) {
+		validationErr.Field = path
+
+		var elementErr *jsonxErr.ElementError
+		if errors.As(validationErr.Err, &elementErr) {
+			validationErr.Field = path + elementErr.Index
+		}
+	}
+
+	return err
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
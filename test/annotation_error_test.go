@@ -0,0 +1,134 @@
+package test
+
+import (
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnnotationErrorReasons(t *testing.T) {
+	jsonx.Close()
+
+	blank := " "
+	past := time.Now().Add(-time.Hour)
+	for _, tc := range []struct {
+		annotation string
+		value      any
+		reason     errors.AnnotationReason
+		rejected   any
+	}{
+		// nil
+		{annotation: "Required", value: (*string)(nil), reason: errors.ReasonNil},
+		{annotation: "NotBlank", value: (*string)(nil), reason: errors.ReasonNil},
+		{annotation: "Positive", value: (*int)(nil), reason: errors.ReasonNil},
+		{annotation: "Future", value: (*time.Time)(nil), reason: errors.ReasonNil},
+		{annotation: "Length(1,2)", value: (*string)(nil), reason: errors.ReasonNil},
+		{annotation: "NotContainsNil", value: []*string{&blank, nil}, reason: errors.ReasonNil, rejected: (*string)(nil)},
+		// empty
+		{annotation: "NotEmpty", value: "", reason: errors.ReasonEmpty},
+		{annotation: "NotContainsEmpty", value: []string{"a", ""}, reason: errors.ReasonEmpty, rejected: ""},
+		{annotation: "Email", value: "", reason: errors.ReasonEmpty},
+		{annotation: "UUID", value: "", reason: errors.ReasonEmpty},
+		{annotation: "Hostname", value: "", reason: errors.ReasonEmpty},
+		// blank
+		{annotation: "NotBlank", value: " \t", reason: errors.ReasonBlank},
+		{annotation: "NotBlank", value: &blank, reason: errors.ReasonBlank},
+		{annotation: "NotContainsBlank", value: []string{"a", " "}, reason: errors.ReasonBlank, rejected: " "},
+		// wrong_type
+		{annotation: "NotBlank", value: 1, reason: errors.ReasonWrongType},
+		{annotation: "Positive", value: "1", reason: errors.ReasonWrongType},
+		{annotation: "Past", value: "2020-01-01", reason: errors.ReasonWrongType},
+		{annotation: "NotContainsNil", value: "a", reason: errors.ReasonWrongType},
+		{annotation: "Email", value: 1, reason: errors.ReasonWrongType},
+		{annotation: "UUID", value: []int{1}, reason: errors.ReasonWrongType, rejected: 1},
+		{annotation: "Min(1)", value: "1", reason: errors.ReasonWrongType},
+		{annotation: "Enum(a)", value: true, reason: errors.ReasonWrongType},
+		{annotation: "MaxBytes(1KB)", value: 1, reason: errors.ReasonWrongType},
+		// format
+		{annotation: "Email", value: "kim", reason: errors.ReasonFormat},
+		{annotation: "UUID", value: "nope", reason: errors.ReasonFormat},
+		{annotation: "URL", value: "example.com", reason: errors.ReasonFormat},
+		{annotation: "URL", value: "https://example.com:080/", reason: errors.ReasonFormat},
+		{annotation: "IP", value: "1.2.3", reason: errors.ReasonFormat},
+		{annotation: "Semver", value: []string{"1.0.0", "1.0"}, reason: errors.ReasonFormat, rejected: "1.0"},
+		// range
+		{annotation: "Positive", value: 0, reason: errors.ReasonRange},
+		{annotation: "NegativeOrZero", value: 0.5, reason: errors.ReasonRange},
+		{annotation: "Min(10)", value: 9, reason: errors.ReasonRange},
+		{annotation: "Max(10)", value: 11.5, reason: errors.ReasonRange},
+		{annotation: "Length(1,2)", value: "abc", reason: errors.ReasonRange},
+		{annotation: "Future", value: past, reason: errors.ReasonRange},
+		{annotation: "Port", value: 70000, reason: errors.ReasonRange},
+		{annotation: "Port", value: "0", reason: errors.ReasonRange},
+		{annotation: "URL", value: "https://example.com:0/", reason: errors.ReasonRange},
+		{annotation: "MaxBytes(1B)", value: "ab", reason: errors.ReasonRange},
+		{annotation: "Email(maxLength=5)", value: "a@example.com", reason: errors.ReasonRange},
+		// not_allowed
+		{annotation: "Enum(a,b)", value: "c", reason: errors.ReasonNotAllowed},
+		{annotation: "Enum(1,2)", value: 3, reason: errors.ReasonNotAllowed},
+		{annotation: "UUID(4)", value: "123e4567-e89b-12d3-a456-426614174000", reason: errors.ReasonNotAllowed},
+		{annotation: "URL(https)", value: "http://example.com", reason: errors.ReasonNotAllowed},
+		{annotation: "IP(public)", value: "10.0.0.1", reason: errors.ReasonNotAllowed},
+		{annotation: "Email(deny=example.com)", value: "a@example.com", reason: errors.ReasonNotAllowed},
+	} {
+		annotation, err := definitions.ConvertToAnnotation(tc.annotation)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var annotationErr *errors.AnnotationError
+		err = annotation.Validate(tc.value)
+		if !stdErrors.As(err, &annotationErr) {
+			t.Fatal("unexpected result1", tc.annotation, tc.value, err)
+		}
+		if annotationErr.Annotation != annotation.Name() || annotationErr.Reason != tc.reason {
+			t.Fatal("unexpected result2", tc.annotation, tc.value, annotationErr.Annotation, annotationErr.Reason)
+		}
+
+		rejected := tc.rejected
+		if rejected == nil {
+			rejected = tc.value
+		}
+		if !reflect.DeepEqual(annotationErr.Value, rejected) {
+			t.Fatal("unexpected result3", tc.annotation, annotationErr.Value)
+		}
+	}
+}
+
+func TestAnnotationErrorThroughValidate(t *testing.T) {
+	jsonx.Close()
+
+	type request struct {
+		Name   string   `json:"name" annotation:"@NotBlank"`
+		Emails []string `json:"emails" annotation:"@Email"`
+		Age    int      `json:"age" annotation:"@Min(20)"`
+	}
+
+	for i, tc := range []struct {
+		request request
+		reason  errors.AnnotationReason
+		detail  string
+	}{
+		{request: request{Name: " ", Age: 20}, reason: errors.ReasonBlank, detail: "blank value"},
+		{request: request{Name: "kim", Emails: []string{"kim"}, Age: 20}, reason: errors.ReasonFormat, detail: "missing '@'"},
+		{request: request{Name: "kim", Age: 19}, reason: errors.ReasonRange, detail: "less than 20"},
+	} {
+		var annotationErr *errors.AnnotationError
+		err := jsonx.Validate(tc.request)
+		if !stdErrors.As(err, &annotationErr) || annotationErr.Reason != tc.reason || annotationErr.Detail != tc.detail {
+			t.Fatal("unexpected result", i, err)
+		}
+	}
+
+	err := errors.NewAnnotationErr("Email", errors.ReasonFormat, "kim", "missing '@'")
+	if err.Error() != "@Email missing '@'" {
+		t.Fatal("unexpected result4", err)
+	}
+	if errors.NewAnnotationErr("Required", errors.ReasonNil, nil, "").Error() != "@Required nil" {
+		t.Fatal("unexpected result5")
+	}
+}
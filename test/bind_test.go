@@ -0,0 +1,153 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"github.com/aivyss/jsonx"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/jsonxhttp"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type paging struct {
+	Page int  `query:"page" annotation:"@Positive"`
+	Size *int `query:"size" annotation:"@Max(100)"`
+}
+
+type listReq struct {
+	paging
+	Tenant  string        `header:"X-Tenant" annotation:"@NotBlank"`
+	Tags    []string      `query:"tag" annotation:"@NotContainsBlank"`
+	IDs     []uint16      `query:"id"`
+	Active  *bool         `query:"active"`
+	Since   time.Time     `query:"since"`
+	Day     *time.Time    `query:"day" layout:"2006-01-02"`
+	Timeout time.Duration `query:"timeout"`
+	Ratio   float32       `query:"ratio"`
+}
+
+func TestBindValues(t *testing.T) {
+	jsonx.Close()
+	query, _ := url.ParseQuery("page=2&size=10&tag=a&tag=b&id=1&id=65535&active=true&since=2020-01-02T03:04:05Z&day=2021-02-03&timeout=1h30m&ratio=0.5")
+	header := http.Header{}
+	header.Set("x-tenant", "acme")
+
+	req, err := jsonx.BindValues[listReq](jsonx.Sources{Query: query, Header: header})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Page != 2 || *req.Size != 10 || req.Tenant != "acme" || !*req.Active || req.Ratio != 0.5 {
+		t.Fatal("unexpected result1", req)
+	}
+	if len(req.Tags) != 2 || req.Tags[1] != "b" || len(req.IDs) != 2 || req.IDs[1] != 65535 {
+		t.Fatal("unexpected result2", req)
+	}
+	if !req.Since.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) || req.Day.Format("2006-01-02") != "2021-02-03" {
+		t.Fatal("unexpected result3", req)
+	}
+	if req.Timeout != 90*time.Minute {
+		t.Fatal("unexpected result4", req)
+	}
+
+	// validation runs after binding
+	query.Set("page", "0")
+	if _, err := jsonx.BindValues[listReq](jsonx.Sources{Query: query, Header: header}); err == nil {
+		t.Fatal("unexpected result5")
+	}
+	query.Set("page", "1")
+	if _, err := jsonx.BindQuery[listReq](query); err == nil {
+		t.Fatal("unexpected result6")
+	}
+
+	// conversion failure
+	query.Set("id", "70000")
+	_, err = jsonx.BindValues[listReq](jsonx.Sources{Query: query, Header: header})
+	var bindErr *jsonxErr.BindError
+	if !errors.As(err, &bindErr) || !errors.Is(err, jsonxErr.ErrUnmarshal) {
+		t.Fatal("unexpected result7")
+	}
+	if bindErr.Source != "query" || bindErr.Key != "id" {
+		t.Fatal("unexpected result8", bindErr)
+	}
+
+	type headerOnly struct {
+		Tenant string `header:"X-Tenant" annotation:"@NotBlank"`
+	}
+	if o, err := jsonx.BindHeader[headerOnly](header); err != nil || o.Tenant != "acme" {
+		t.Fatal("unexpected result9")
+	}
+}
+
+type bindNode struct {
+	Name     string    `query:"name"`
+	Next     *bindNode `json:"next"`
+	Children []bindNode
+	Meta     *struct {
+		Parent *bindNode
+		Label  string `query:"label"`
+	}
+}
+
+func TestBindRecursive(t *testing.T) {
+	jsonx.Close()
+	query, _ := url.ParseQuery("name=root&label=x")
+
+	node, err := jsonx.BindQuery[bindNode](query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Name != "root" || node.Next != nil || node.Meta == nil || node.Meta.Label != "x" || node.Meta.Parent != nil {
+		t.Fatal("unexpected result1", node)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/nodes?name=query", strings.NewReader(`{"next": {}}`))
+	req.Header.Set("Content-Type", "application/json")
+	if node, err := jsonxhttp.Bind[bindNode](req); err != nil || node.Name != "query" || node.Next == nil {
+		t.Fatal("unexpected result2", node, err)
+	}
+}
+
+func TestBindRequest(t *testing.T) {
+	jsonx.Close()
+	type updateReq struct {
+		ID     int    `path:"id" annotation:"@Positive"`
+		Tenant string `header:"X-Tenant" annotation:"@NotBlank"`
+		Dry    bool   `query:"dry"`
+		Name   string `json:"name" annotation:"@NotBlank"`
+	}
+	pathValues := jsonxhttp.WithPathValues(func(r *http.Request) map[string]string {
+		return map[string]string{"id": strings.TrimPrefix(r.URL.Path, "/users/")}
+	})
+
+	r := httptest.NewRequest(http.MethodPut, "/users/7?dry=true", strings.NewReader(`{ "name": "a" }`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Tenant", "acme")
+	req, err := jsonxhttp.Bind[updateReq](r, pathValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.ID != 7 || req.Tenant != "acme" || !req.Dry || req.Name != "a" {
+		t.Fatal("unexpected result1", req)
+	}
+
+	type getReq struct {
+		ID int `path:"id" annotation:"@Positive"`
+	}
+	handler := jsonxhttp.Handle(func(ctx context.Context, req *getReq) (any, error) {
+		return req, nil
+	}, pathValues)
+
+	for path, status := range map[string]int{"/users/3": 200, "/users/0": 422, "/users/x": 400} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != status {
+			t.Fatal("unexpected status", path, recorder.Code)
+		}
+	}
+}
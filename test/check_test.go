@@ -0,0 +1,94 @@
+package test
+
+import (
+	"errors"
+	"github.com/aivyss/jsonx"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("[valid type]", func(t *testing.T) {
+		jsonx.Close()
+		jsonx.RegisterFieldError("nameErr", "name is wrong")
+		type inner struct {
+			At time.Time `json:"at" annotation:"@Future"`
+		}
+		type testStruct struct {
+			Name  *string  `json:"name" annotation:"@NotBlank" fieldErr:"nameErr"`
+			Email string   `json:"email" annotation:"@Email" pattern:"^.+$"`
+			Count int      `json:"count" annotation:"@Positive"`
+			Tags  []string `json:"tags" annotation:"@NotContainsBlank"`
+			Inner *inner   `json:"inner"`
+		}
+
+		if err := jsonx.Check[testStruct](); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("[every problem]", func(t *testing.T) {
+		jsonx.Close()
+		type elem struct {
+			Value string `json:"value" annotation:"@NotBlank"`
+		}
+		type inner struct {
+			Value int `json:"value" annotation:"@Future"`
+		}
+		type testStruct struct {
+			Unknown string         `json:"unknown" annotation:"@Apple"`
+			Email   int            `json:"email" annotation:"@Email"`
+			Regex   string         `json:"regex" pattern:"[a-"`
+			FieldE  string         `json:"fieldE" fieldErr:"noSuchErr"`
+			Elems   []elem         `json:"elems"`
+			Ch      chan int       `json:"-" annotation:"@Required"`
+			Inner   inner          `json:"inner"`
+			Map     map[string]int `json:"map" annotation:"@NotBlank"`
+		}
+
+		err := jsonx.Check[testStruct]()
+		if err == nil {
+			t.Fatal("unexpected result1")
+		}
+
+		joined, ok := err.(interface{ Unwrap() []error })
+		if !ok {
+			t.Fatal("unexpected result2")
+		}
+
+		fields := map[string]bool{}
+		for _, problem := range joined.Unwrap() {
+			var tagErr *jsonxErr.TagError
+			if !errors.As(problem, &tagErr) {
+				t.Fatal("unexpected result3")
+			}
+			fields[tagErr.Field] = true
+		}
+
+		for _, field := range []string{"Unknown", "Email", "Regex", "FieldE", "Elems", "Ch", "Value", "Map"} {
+			if !fields[field] {
+				t.Fatalf("problem of %s is not reported: %s", field, err)
+			}
+		}
+
+		if !strings.Contains(err.Error(), "@Email does not support int") {
+			t.Fatal("unexpected result4")
+		}
+	})
+
+	t.Run("[MustRegisterType]", func(t *testing.T) {
+		jsonx.Close()
+		type testStruct struct {
+			Value string `json:"value" annotation:"@Positive"`
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Fatal("unexpected result")
+			}
+		}()
+		jsonx.MustRegisterType[testStruct]()
+	})
+}
@@ -0,0 +1,309 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/civil"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/jsonschema"
+	"github.com/aivyss/jsonx/jsonxtest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type billing struct {
+	IssuedOn  jsonx.Date       `json:"issuedOn" annotation:"@PastOrPresent"`
+	DueOn     *jsonx.Date      `json:"dueOn" annotation:"@Between(2024-01-01, 2024-12-31)"`
+	SendAt    jsonx.TimeOfDay  `json:"sendAt" annotation:"@Between(09:00, 18:00)"`
+	Grace     jsonx.Duration   `json:"grace" annotation:"@Min(1h) @Max(72h)"`
+	Timeout   time.Duration    `json:"timeout" annotation:"@Max(30s)"`
+	CutoffAt  *jsonx.TimeOfDay `json:"cutoffAt" annotation:"@Min(06:00)"`
+	StartedOn jsonx.Date       `json:"startedOn" annotation:"@Min(2020-01-01)"`
+}
+
+func TestCivilTypes(t *testing.T) {
+	jsonx.Close()
+
+	t.Run("[json]", func(t *testing.T) {
+		type value struct {
+			Date     jsonx.Date      `json:"date"`
+			Time     jsonx.TimeOfDay `json:"time"`
+			Duration jsonx.Duration  `json:"duration"`
+		}
+		v := value{
+			Date:     jsonx.Date{Year: 2024, Month: time.February, Day: 29},
+			Time:     jsonx.TimeOfDay{Hour: 9, Minute: 30, Nanosecond: 500_000_000},
+			Duration: jsonx.Duration(90 * time.Minute),
+		}
+		j, err := jsonx.Marshal(v)
+		if err != nil || string(j) != `{"date":"2024-02-29","time":"09:30:00.5","duration":"1h30m0s"}` {
+			t.Fatal("unexpected result1", string(j), err)
+		}
+
+		decoded, err := jsonx.Unmarshal[value](j)
+		if err != nil || *decoded != v {
+			t.Fatal("unexpected result2", decoded, err)
+		}
+
+		decoded, err = jsonx.Unmarshal[value]([]byte(`{"date":"2024-02-29","time":"09:30","duration":"1h30m"}`))
+		if err != nil || decoded.Time != (jsonx.TimeOfDay{Hour: 9, Minute: 30}) || decoded.Duration != v.Duration {
+			t.Fatal("unexpected result3", decoded, err)
+		}
+		decoded, err = jsonx.Unmarshal[value]([]byte(`{"duration":5400000000000}`))
+		if err != nil || decoded.Duration != v.Duration {
+			t.Fatal("unexpected result4", decoded, err)
+		}
+
+		for _, data := range []string{
+			`{"date":"2023-02-29"}`, `{"date":"2024-2-1"}`, `{"time":"24:00"}`, `{"time":"9:30"}`,
+			`{"duration":"90"}`, `{"duration":true}`,
+		} {
+			if _, err := jsonx.Unmarshal[value]([]byte(data)); !stdErrors.Is(err, errors.ErrUnmarshal) {
+				t.Fatal("unexpected result5", data, err)
+			}
+		}
+
+		if _, err := jsonx.Marshal(value{Date: jsonx.Date{Year: 2023, Month: time.February, Day: 29}}); err == nil {
+			t.Fatal("unexpected result6")
+		}
+
+		j, err = json.Marshal(struct {
+			D jsonx.Date `json:"d,omitempty"`
+		}{})
+		if err != nil || string(j) != `{"d":null}` {
+			t.Fatal("unexpected result7", string(j), err)
+		}
+		cleared := value{Date: v.Date}
+		if err := json.Unmarshal([]byte(`{"date":null}`), &cleared); err != nil || !cleared.Date.IsZero() {
+			t.Fatal("unexpected result8", cleared, err)
+		}
+	})
+
+	t.Run("[date]", func(t *testing.T) {
+		d := civil.DateOf(time.Date(2024, time.February, 29, 23, 0, 0, 0, time.UTC))
+		if d.String() != "2024-02-29" || !d.IsValid() || d.IsZero() {
+			t.Fatal("unexpected result1", d)
+		}
+		if next := d.AddDays(1); next.String() != "2024-03-01" || !next.After(d) || !d.Before(next) {
+			t.Fatal("unexpected result2", next)
+		}
+		if d.AddDays(366).Compare(jsonx.Date{Year: 2025, Month: time.March, Day: 1}) != 0 {
+			t.Fatal("unexpected result3")
+		}
+
+		seoul := time.FixedZone("KST", 9*60*60)
+		if !d.In(seoul).Equal(time.Date(2024, time.February, 28, 15, 0, 0, 0, time.UTC)) {
+			t.Fatal("unexpected result4")
+		}
+		noon := jsonx.TimeOfDay{Hour: 12}
+		if !noon.On(d, time.UTC).Equal(time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC)) || noon.String() != "12:00:00" {
+			t.Fatal("unexpected result5")
+		}
+	})
+
+	t.Run("[annotations]", func(t *testing.T) {
+		jsonxtest.UseClock(t, time.Date(2024, time.June, 1, 23, 30, 0, 0, time.UTC))
+
+		if err := jsonx.Check[billing](); err != nil {
+			t.Fatal(err)
+		}
+
+		dueOn := jsonx.Date{Year: 2024, Month: time.June, Day: 30}
+		cutoffAt := jsonx.TimeOfDay{Hour: 6}
+		valid := billing{
+			IssuedOn:  jsonx.Date{Year: 2024, Month: time.June, Day: 1},
+			DueOn:     &dueOn,
+			SendAt:    jsonx.TimeOfDay{Hour: 18},
+			Grace:     jsonx.Duration(time.Hour),
+			Timeout:   30 * time.Second,
+			CutoffAt:  &cutoffAt,
+			StartedOn: jsonx.Date{Year: 2020, Month: time.January, Day: 1},
+		}
+		if err := jsonx.Validate(valid); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+
+		lateDue := jsonx.Date{Year: 2025, Month: time.January, Day: 1}
+		early := jsonx.TimeOfDay{Hour: 5, Minute: 59, Second: 59}
+		for i, tc := range []struct {
+			modify func(b *billing)
+			field  string
+			reason errors.AnnotationReason
+		}{
+			{modify: func(b *billing) { b.IssuedOn = jsonx.Date{Year: 2024, Month: time.June, Day: 2} }, field: "issuedOn", reason: errors.ReasonRange},
+			{modify: func(b *billing) { b.DueOn = &lateDue }, field: "dueOn", reason: errors.ReasonRange},
+			{modify: func(b *billing) { b.DueOn = nil }, field: "dueOn", reason: errors.ReasonNil},
+			{modify: func(b *billing) { b.SendAt = jsonx.TimeOfDay{Hour: 18, Nanosecond: 1} }, field: "sendAt", reason: errors.ReasonRange},
+			{modify: func(b *billing) { b.Grace = jsonx.Duration(59 * time.Minute) }, field: "grace", reason: errors.ReasonRange},
+			{modify: func(b *billing) { b.Grace = jsonx.Duration(73 * time.Hour) }, field: "grace", reason: errors.ReasonRange},
+			{modify: func(b *billing) { b.Timeout = time.Minute }, field: "timeout", reason: errors.ReasonRange},
+			{modify: func(b *billing) { b.CutoffAt = &early }, field: "cutoffAt", reason: errors.ReasonRange},
+			{modify: func(b *billing) { b.StartedOn = jsonx.Date{Year: 2019, Month: time.December, Day: 31} }, field: "startedOn", reason: errors.ReasonRange},
+		} {
+			b := valid
+			tc.modify(&b)
+
+			var annotationErr *errors.AnnotationError
+			var validationErr *errors.ValidationError
+			err := jsonx.Validate(b)
+			if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field ||
+				!stdErrors.As(err, &annotationErr) || annotationErr.Reason != tc.reason {
+				t.Fatal("unexpected result2", i, err)
+			}
+		}
+	})
+
+	t.Run("[future and past dates]", func(t *testing.T) {
+		// 2024-06-02 08:30 in Seoul
+		jsonxtest.UseClock(t, time.Date(2024, time.June, 1, 23, 30, 0, 0, time.UTC).In(time.FixedZone("KST", 9*60*60)))
+
+		today := jsonx.Date{Year: 2024, Month: time.June, Day: 2}
+		for annoStr, cases := range map[string]map[jsonx.Date]bool{
+			"Future":              {today: false, today.AddDays(1): true},
+			"Past":                {today: false, today.AddDays(-1): true},
+			"Present":             {today: true, today.AddDays(1): false},
+			"FutureOrPresent(5s)": {today: true, today.AddDays(-1): false},
+			"Present(month)":      {{Year: 2024, Month: time.June, Day: 30}: true, {Year: 2024, Month: time.May, Day: 31}: false},
+		} {
+			annotation, err := definitions.ConvertToAnnotation(annoStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for value, valid := range cases {
+				if err := annotation.Validate(value); valid != (err == nil) {
+					t.Fatal("unexpected result", annoStr, value, err)
+				}
+			}
+		}
+	})
+
+	t.Run("[wrong types]", func(t *testing.T) {
+		type durationAsNumber struct {
+			Timeout time.Duration `json:"timeout" annotation:"@Min(5)"`
+		}
+		type numberAsDuration struct {
+			Count int `json:"count" annotation:"@Min(1m)"`
+		}
+		type windowOnDate struct {
+			Day jsonx.Date `json:"day" annotation:"@PastWithin(24h)"`
+		}
+		type timeBetweenDates struct {
+			At jsonx.TimeOfDay `json:"at" annotation:"@Between(2024-01-01, 2024-12-31)"`
+		}
+		for i, err := range []error{
+			jsonx.Check[durationAsNumber](), jsonx.Check[numberAsDuration](), jsonx.Check[windowOnDate](), jsonx.Check[timeBetweenDates](),
+		} {
+			if err == nil {
+				t.Fatal("unexpected result", i)
+			}
+		}
+
+		type mixedBounds struct {
+			At jsonx.TimeOfDay `json:"at" annotation:"@Between(09:00, 1h)"`
+		}
+		type reversedBounds struct {
+			At jsonx.TimeOfDay `json:"at" annotation:"@Between(18:00, 09:00)"`
+		}
+		type layoutOnDurations struct {
+			Grace jsonx.Duration `json:"grace" annotation:"@Between(1m, 1h, RFC3339)"`
+		}
+		for i, err := range []error{jsonx.Check[mixedBounds](), jsonx.Check[reversedBounds](), jsonx.Check[layoutOnDurations]()} {
+			if err == nil {
+				t.Fatal("unexpected result", i)
+			}
+		}
+	})
+
+	t.Run("[bind]", func(t *testing.T) {
+		type query struct {
+			Day   jsonx.Date      `query:"day"`
+			At    jsonx.TimeOfDay `query:"at"`
+			Grace jsonx.Duration  `query:"grace"`
+		}
+		q, err := jsonx.BindValues[query](jsonx.Sources{Query: url.Values{"day": {"2024-02-29"}, "at": {"09:30"}, "grace": {"1h30m"}}})
+		if err != nil || q.Day.String() != "2024-02-29" || q.At.String() != "09:30:00" || q.Grace.String() != "1h30m0s" {
+			t.Fatal("unexpected result", q, err)
+		}
+	})
+}
+
+func TestCivilSchemas(t *testing.T) {
+	jsonx.Close()
+
+	schema, err := jsonx.Schema[billing]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := schema["properties"].(map[string]any)
+	assertJSONEqual(t, properties["issuedOn"], `{"type": ["string", "null"], "format": "date"}`)
+	assertJSONEqual(t, properties["dueOn"], `{"type": "string", "format": "date"}`)
+	assertJSONEqual(t, properties["timeout"], `{"type": "integer", "maximum": 30000000000}`)
+
+	timeOfDay := regexp.MustCompile(properties["sendAt"].(map[string]any)["pattern"].(string))
+	for value, valid := range map[string]bool{"09:30": true, "23:59:59.999999999": true, "24:00": false, "9:30": false} {
+		if timeOfDay.MatchString(value) != valid {
+			t.Fatal("unexpected result1", value)
+		}
+	}
+	duration := regexp.MustCompile(properties["grace"].(map[string]any)["pattern"].(string))
+	for value, valid := range map[string]bool{"1h30m": true, "-1.5s": true, "0": true, "90": false, "1d": false, "00": false} {
+		if duration.MatchString(value) != valid {
+			t.Fatal("unexpected result2", value)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := jsonx.WriteTypeScript(buf, reflect.TypeOf(billing{})); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"  issuedOn: z.string().date().nullable(),\n",
+		`  dueOn: z.string().date().refine((v) => new Date(v).getTime() >= Date.parse("2024-01-01") && new Date(v).getTime() <= Date.parse("2024-12-31")`,
+		"  timeout: z.number().int().max(30000000000),\n",
+		"  grace: z.string().regex(",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatal("unexpected result3", expected, buf.String())
+		}
+	}
+}
+
+func TestCivilSchemaRoundTrip(t *testing.T) {
+	jsonx.Close()
+
+	generated, err := jsonx.Schema[billing]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	document, err := json.Marshal(generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := jsonschema.Compile(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuedOn := jsonx.Date{Year: 2024, Month: time.March, Day: 1}
+	cutoffAt := jsonx.TimeOfDay{Hour: 7}
+	for i, value := range []billing{
+		{DueOn: &issuedOn, CutoffAt: &cutoffAt},
+		{IssuedOn: issuedOn, DueOn: &issuedOn, StartedOn: issuedOn, Grace: jsonx.Duration(time.Hour), CutoffAt: &cutoffAt},
+	} {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := schema.Validate(encoded); err != nil {
+			t.Fatal("unexpected result", i, string(encoded), err)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package test
+
+import (
+	"bytes"
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/jsonxtest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	jsonx.Close()
+
+	now := time.Date(2024, time.February, 29, 12, 30, 15, 500_000_000, time.UTC)
+
+	t.Run("[fake clock]", func(t *testing.T) {
+		clock := jsonxtest.UseClock(t, now)
+
+		type testStruct struct {
+			Value time.Time `json:"value" annotation:"@Present"`
+		}
+		if err := jsonx.Validate(testStruct{Value: now.Add(400 * time.Millisecond)}); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+		if err := jsonx.Validate(testStruct{Value: now.Add(-time.Second)}); err == nil {
+			t.Fatal("unexpected result2")
+		}
+
+		clock.Advance(-time.Second)
+		if err := jsonx.Validate(testStruct{Value: now.Add(-time.Second)}); err != nil {
+			t.Fatal("unexpected result3", err)
+		}
+
+		clock.Set(now.AddDate(1, 0, 0))
+		if err := jsonx.Validate(testStruct{Value: now}); err == nil {
+			t.Fatal("unexpected result4")
+		}
+	})
+
+	t.Run("[restore]", func(t *testing.T) {
+		jsonx.SetClock(definitions.ClockFunc(func() time.Time { return now }))
+		jsonx.SetClock(nil)
+
+		type testStruct struct {
+			Value time.Time `json:"value" annotation:"@Future"`
+		}
+		if err := jsonx.Validate(testStruct{Value: time.Now().Add(time.Hour)}); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+		if err := jsonx.Validate(testStruct{Value: now}); err == nil {
+			t.Fatal("unexpected result2")
+		}
+	})
+
+	t.Run("[tolerance]", func(t *testing.T) {
+		jsonxtest.UseClock(t, now)
+
+		for annoStr, cases := range map[string]map[time.Duration]bool{
+			"Present(5s)":          {-5 * time.Second: true, 5 * time.Second: true, 6 * time.Second: false, -time.Minute: false},
+			"Future(5s)":           {5 * time.Second: false, 6 * time.Second: true, time.Hour: true},
+			"Past(1m)":             {-time.Minute: false, -2 * time.Minute: true, time.Second: false},
+			"FutureOrPresent(1m)":  {-time.Minute: true, -2 * time.Minute: false, time.Hour: true},
+			"PastOrPresent(500ms)": {500 * time.Millisecond: true, time.Second: false, -time.Hour: true},
+		} {
+			annotation, err := definitions.ConvertToAnnotation(annoStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for offset, valid := range cases {
+				err := annotation.Validate(now.Add(offset))
+				if valid != (err == nil) {
+					t.Fatal("unexpected result", annoStr, offset, err)
+				}
+			}
+		}
+	})
+
+	t.Run("[default tolerance]", func(t *testing.T) {
+		boundary := time.Date(2024, time.February, 29, 12, 0, 0, 999_000_000, time.UTC)
+		jsonxtest.UseClock(t, boundary)
+
+		for annoStr, cases := range map[string]map[time.Duration]bool{
+			"Present":         {2 * time.Millisecond: true, -2 * time.Millisecond: true, 999 * time.Millisecond: true, time.Second: false, -time.Second: false},
+			"Future":          {2 * time.Millisecond: false, time.Second: true},
+			"Past":            {-2 * time.Millisecond: false, -time.Second: true},
+			"FutureOrPresent": {-999 * time.Millisecond: true, -time.Second: false},
+			"PastOrPresent":   {999 * time.Millisecond: true, time.Second: false},
+		} {
+			annotation, err := definitions.ConvertToAnnotation(annoStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for offset, valid := range cases {
+				err := annotation.Validate(boundary.Add(offset))
+				if valid != (err == nil) {
+					t.Fatal("unexpected result", annoStr, offset, err)
+				}
+			}
+		}
+	})
+
+	t.Run("[granularity]", func(t *testing.T) {
+		jsonxtest.UseClock(t, now)
+
+		seoul := time.FixedZone("KST", 9*60*60)
+		for annoStr, cases := range map[string]map[time.Time]bool{
+			"Present(day)": {
+				time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC):    true,
+				time.Date(2024, time.February, 29, 23, 59, 59, 0, time.UTC): true,
+				time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC):        false,
+				time.Date(2024, time.February, 29, 9, 0, 0, 0, seoul):       true,
+				time.Date(2024, time.February, 29, 8, 59, 59, 0, seoul):     false,
+				time.Date(2023, time.February, 28, 12, 30, 15, 0, time.UTC): false,
+			},
+			"Future(day)": {
+				time.Date(2024, time.February, 29, 23, 59, 59, 0, time.UTC): false,
+				time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC):        true,
+			},
+			"PastOrPresent(month)": {
+				time.Date(2024, time.February, 29, 23, 0, 0, 0, time.UTC): true,
+				time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC):      false,
+			},
+			"Past(year)": {
+				time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC):    false,
+				time.Date(2023, time.December, 31, 23, 0, 0, 0, time.UTC): true,
+			},
+			"Present(hour)": {
+				time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC):  true,
+				time.Date(2024, time.February, 29, 13, 0, 0, 0, time.UTC):  false,
+				time.Date(2024, time.February, 29, 11, 59, 0, 0, time.UTC): false,
+			},
+			"FutureOrPresent(minute)": {
+				time.Date(2024, time.February, 29, 12, 30, 0, 0, time.UTC):  true,
+				time.Date(2024, time.February, 29, 12, 29, 59, 0, time.UTC): false,
+			},
+		} {
+			annotation, err := definitions.ConvertToAnnotation(annoStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for value, valid := range cases {
+				err := annotation.Validate(value)
+				if valid != (err == nil) {
+					t.Fatal("unexpected result", annoStr, value, err)
+				}
+			}
+		}
+	})
+
+	t.Run("[strings]", func(t *testing.T) {
+		jsonxtest.UseClock(t, now)
+
+		type event struct {
+			Day  string `json:"day" annotation:"@Present(DateOnly, day)"`
+			Sent string `json:"sent" annotation:"@PastOrPresent(RFC3339, 2s)"`
+		}
+		if err := jsonx.Check[event](); err != nil {
+			t.Fatal(err)
+		}
+
+		valid := event{Day: "2024-02-29", Sent: "2024-02-29T12:30:17Z"}
+		if err := jsonx.Validate(valid); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+
+		for i, tc := range []struct {
+			event event
+			field string
+		}{
+			{event: event{Day: "2024-03-01", Sent: valid.Sent}, field: "day"},
+			{event: event{Day: valid.Day, Sent: "2024-02-29T12:30:18Z"}, field: "sent"},
+		} {
+			var annotationErr *errors.AnnotationError
+			var validationErr *errors.ValidationError
+			err := jsonx.Validate(tc.event)
+			if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field ||
+				!stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonRange {
+				t.Fatal("unexpected result2", i, err)
+			}
+		}
+	})
+
+	t.Run("[wrong arguments]", func(t *testing.T) {
+		for _, annoStr := range []string{
+			"Present(5s,day)", "Present(day,month)", "Future(1s,2s)", "Past(-5s)", "Past(0s)", "Future(week)",
+		} {
+			if _, err := definitions.ConvertToAnnotation(annoStr); err == nil {
+				t.Fatal("unexpected result", annoStr)
+			}
+		}
+	})
+}
+
+func TestTemporalTypeScript(t *testing.T) {
+	jsonx.Close()
+
+	type tsEvent struct {
+		Sent time.Time `json:"sent" annotation:"@Present(5s)"`
+		Due  time.Time `json:"due" annotation:"@Future(1m)"`
+		Day  time.Time `json:"day" annotation:"@Present(day)"`
+	}
+	buf := &bytes.Buffer{}
+	if err := jsonx.WriteTypeScript(buf, reflect.TypeOf(tsEvent{})); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`sent: z.string().datetime({ offset: true }).refine((v) => Math.abs(new Date(v).getTime() - Date.now()) <= 5000`,
+		`due: z.string().datetime({ offset: true }).refine((v) => new Date(v).getTime() > Date.now() + 60000`,
+		`day: z.string().datetime({ offset: true }),`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatal("unexpected result", expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package test
+
+import (
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var dateTimeCases = []formatCase{
+	{
+		annotation: "DateTime(RFC3339)",
+		valid:      []string{"2024-02-29T12:30:00Z", "2024-02-29T12:30:00+09:00", "2024-02-29T12:30:00.123Z"},
+		invalid: map[string]string{
+			"2024-02-29":           `"2024-02-29" does not match`,
+			"2024-02-29 12:30:00Z": `"2024-02-29 12:30:00Z" does not match`,
+			"2023-02-29T12:30:00Z": "day out of range",
+			"2024-02-29T25:30:00Z": "hour out of range",
+		},
+	},
+	{
+		annotation: "DateTime(RFC1123)",
+		valid:      []string{"Mon, 02 Jan 2006 15:04:05 MST", "Thu, 29 Feb 2024 12:30:00 GMT"},
+		invalid: map[string]string{
+			"2024-02-29T12:30:00Z": "does not match",
+		},
+	},
+	{
+		annotation: "DateTime('02/01/2006 15:04')",
+		valid:      []string{"29/02/2024 12:30", "01/12/1999 00:00"},
+		invalid: map[string]string{
+			"2024-02-29 12:30": "does not match",
+			"31/04/2024 12:30": "day out of range",
+		},
+	},
+	{
+		annotation: "Date",
+		valid:      []string{"2024-02-29", "1999-12-31"},
+		invalid: map[string]string{
+			"2023-02-29":           "day out of range",
+			"2024-13-01":           "month out of range",
+			"2024-1-01":            `"2024-1-01" does not match "2006-01-02"`,
+			"2024-02-29T00:00:00Z": "extra text",
+		},
+	},
+	{
+		annotation: "RFC3339",
+		valid:      []string{"2024-02-29T12:30:00Z", "2024-02-29T12:30:00.999999999-07:00"},
+		invalid: map[string]string{
+			"2024-02-29T12:30:00":       "does not match",
+			"2024-02-29T12:30:00+0900":  "does not match",
+			"Thu, 29 Feb 2024 12:30:00": "does not match",
+		},
+	},
+	{
+		annotation: "Timezone",
+		valid:      []string{"UTC", "Asia/Seoul", "America/New_York", "Europe/Berlin"},
+		invalid: map[string]string{
+			"Local":           `unknown time zone "Local"`,
+			"Asia/Atlantis":   `unknown time zone "Asia/Atlantis"`,
+			"../etc/passwd":   `unknown time zone "../etc/passwd"`,
+			"/usr/share/zone": `unknown time zone "/usr/share/zone"`,
+		},
+	},
+}
+
+func TestDateTimeFormats(t *testing.T) {
+	jsonx.Close()
+
+	for _, tc := range dateTimeCases {
+		annotation, err := definitions.ConvertToAnnotation(tc.annotation)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("["+tc.annotation+"]", func(t *testing.T) {
+			for _, value := range tc.valid {
+				if err := annotation.Validate(value); err != nil {
+					t.Fatal("unexpected result1", value, err)
+				}
+				if err := annotation.Validate([]string{value}); err != nil {
+					t.Fatal("unexpected result2", value, err)
+				}
+			}
+
+			for value, reason := range tc.invalid {
+				var annotationErr *errors.AnnotationError
+				err := annotation.Validate(value)
+				if !stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonFormat || !strings.Contains(err.Error(), reason) {
+					t.Fatal("unexpected result3", value, err)
+				}
+			}
+
+			if err := annotation.Validate((*string)(nil)); err != nil {
+				t.Fatal("unexpected result4", err)
+			}
+		})
+	}
+
+	t.Run("[wrong arguments]", func(t *testing.T) {
+		for _, annoStr := range []string{"DateTime", "DateTime(banana)", "DateTime(RFC3339,DateOnly)", "Date(RFC3339)", "Future(banana)", "Past(RFC3339,DateOnly)"} {
+			if _, err := definitions.ConvertToAnnotation(annoStr); err == nil {
+				t.Fatal("unexpected result", annoStr)
+			}
+		}
+	})
+}
+
+func TestTemporalStrings(t *testing.T) {
+	jsonx.Close()
+
+	type event struct {
+		StartsAt string     `json:"startsAt" annotation:"@Future(RFC3339)"`
+		Born     *string    `json:"born" annotation:"@Past('02.01.2006')"`
+		Seen     string     `json:"seen" annotation:"@PastOrPresent(DateOnly)"`
+		At       *time.Time `json:"at" annotation:"@FutureOrPresent"`
+	}
+	if err := jsonx.Check[event](); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	born := "24.12.1990"
+	valid := event{
+		StartsAt: now.Add(time.Hour).Format(time.RFC3339),
+		Born:     &born,
+		Seen:     now.Format(time.DateOnly),
+		At:       &now,
+	}
+	if err := jsonx.Validate(valid); err != nil {
+		t.Fatal("unexpected result1", err)
+	}
+
+	future := "24.12.2990"
+	for i, tc := range []struct {
+		event  event
+		field  string
+		reason errors.AnnotationReason
+	}{
+		{event: event{StartsAt: now.Add(-time.Hour).Format(time.RFC3339), Born: &born, Seen: valid.Seen, At: &now}, field: "startsAt", reason: errors.ReasonRange},
+		{event: event{StartsAt: "tomorrow", Born: &born, Seen: valid.Seen, At: &now}, field: "startsAt", reason: errors.ReasonFormat},
+		{event: event{StartsAt: valid.StartsAt, Born: &future, Seen: valid.Seen, At: &now}, field: "born", reason: errors.ReasonRange},
+		{event: event{StartsAt: valid.StartsAt, Seen: valid.Seen, At: &now}, field: "born", reason: errors.ReasonNil},
+		{event: event{StartsAt: valid.StartsAt, Born: &born, Seen: "", At: &now}, field: "seen", reason: errors.ReasonEmpty},
+	} {
+		var validationErr *errors.ValidationError
+		var annotationErr *errors.AnnotationError
+		err := jsonx.Validate(tc.event)
+		if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field ||
+			!stdErrors.As(err, &annotationErr) || annotationErr.Reason != tc.reason {
+			t.Fatal("unexpected result2", i, err)
+		}
+	}
+
+	type wrong struct {
+		StartsAt string `json:"startsAt" annotation:"@Future"`
+	}
+	if err := jsonx.Check[wrong](); err == nil || !strings.Contains(err.Error(), "@Future does not support string") {
+		t.Fatal("unexpected result3", err)
+	}
+
+	schema, err := jsonx.Schema[event]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := schema["properties"].(map[string]any)
+	assertJSONEqual(t, properties["startsAt"], `{"type": "string", "format": "date-time"}`)
+	assertJSONEqual(t, properties["born"], `{"type": "string"}`)
+	assertJSONEqual(t, properties["seen"], `{"type": "string", "format": "date"}`)
+}
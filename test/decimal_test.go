@@ -0,0 +1,196 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecimalAnnotations(t *testing.T) {
+	jsonx.Close()
+
+	for annoStr, cases := range map[string]map[any]bool{
+		"Digits(3,2)": {
+			json.Number("123.45"): true, json.Number("999.99"): true, json.Number("1000"): false, json.Number("-1000"): false,
+			json.Number("1.234"): false, json.Number("1.230"): true, "-999.5": true, "12.345": false,
+			0.1: true, 123.45: true, float32(1.5): true, 0.125: false, cents(999): true, cents(1000): false,
+			big.NewRat(1, 3): false, big.NewRat(1, 4): true, big.NewInt(1000): false, math.Inf(1): false, math.NaN(): false,
+		},
+		"Digits(0,2)": {
+			0.5: true, 1.0: false, json.Number("-0.99"): true,
+		},
+		"Scale(2)": {
+			json.Number("10.05"): true, json.Number("10.005"): false, json.Number("1e-2"): true, json.Number("1e-3"): false,
+			"10.50": true, "10.500": true, "abc": false, "": false, "1/2": false, 10.05: true, 0.30000000000000004: false, level(7): true,
+		},
+		"Scale(0)": {
+			json.Number("1e3"): true, json.Number("0.5"): false, 2.0: true,
+		},
+		"MultipleOf(0.05)": {
+			json.Number("10.05"): true, json.Number("10.10"): true, json.Number("10.01"): false, "-0.15": true,
+			0.15: true, 0.35: true, 0.07: false, big.NewRat(1, 20): true, big.NewRat(1, 30): false, cents(3): true,
+		},
+		"MultipleOf(3)": {
+			cents(9): true, cents(10): false, level(0): true, json.Number("1.5"): false,
+		},
+		"Currency": {
+			"USD": true, "EUR": true, "usd": false, "ABC": false, "": false,
+			"10.05 USD": true, "10.005 USD": false, "100 JPY": true, "100.5 JPY": false, "1.005 KWD": true,
+			"1.12345 XAU": true, "10.05": false, "10.05 ABC": false,
+		},
+		"Currency(USD)": {
+			json.Number("10.05"): true, json.Number("10.005"): false, json.Number("10.050"): true, "10.05": true,
+			"10.005": false, "10.05 USD": true, "10.05 EUR": false, 10.05: true, 10.005: false, cents(1005): true,
+		},
+		"Currency(JPY)": {
+			json.Number("100"): true, json.Number("100.5"): false, "100 JPY": true,
+		},
+		"Currency(BHD)": {
+			json.Number("1.005"): true, json.Number("1.0005"): false,
+		},
+		"Currency(XAU)": {
+			json.Number("1.123456789"): true,
+		},
+	} {
+		annotation, err := definitions.ConvertToAnnotation(annoStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for value, valid := range cases {
+			if err := annotation.Validate(value); valid != (err == nil) {
+				t.Fatal("unexpected result1", annoStr, value, err)
+			}
+		}
+	}
+
+	t.Run("[reasons]", func(t *testing.T) {
+		var annotationErr *errors.AnnotationError
+		for i, tc := range []struct {
+			annoStr string
+			value   any
+			reason  errors.AnnotationReason
+		}{
+			{annoStr: "Scale(2)", value: json.Number("10.005"), reason: errors.ReasonRange},
+			{annoStr: "Scale(2)", value: "ten", reason: errors.ReasonFormat},
+			{annoStr: "Scale(2)", value: "", reason: errors.ReasonEmpty},
+			{annoStr: "Scale(2)", value: math.NaN(), reason: errors.ReasonRange},
+			{annoStr: "Scale(2)", value: true, reason: errors.ReasonWrongType},
+			{annoStr: "Currency(USD)", value: "10.05 EUR", reason: errors.ReasonNotAllowed},
+			{annoStr: "Currency", value: "US", reason: errors.ReasonFormat},
+		} {
+			annotation, err := definitions.ConvertToAnnotation(tc.annoStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := annotation.Validate(tc.value); !stdErrors.As(err, &annotationErr) || annotationErr.Reason != tc.reason {
+				t.Fatal("unexpected result1", i, err)
+			}
+		}
+
+		annotation, err := definitions.ConvertToAnnotation("Scale(2)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := annotation.Validate((*json.Number)(nil)); err != nil {
+			t.Fatal("unexpected result2", err)
+		}
+		if err := annotation.Validate([]string{"1.5", "1.505"}); err == nil {
+			t.Fatal("unexpected result3")
+		}
+	})
+
+	t.Run("[wrong arguments]", func(t *testing.T) {
+		for _, annoStr := range []string{
+			"Digits(3)", "Digits(-1,2)", "Digits(a,2)", "Scale", "Scale(-1)", "Scale(1.5)",
+			"MultipleOf(0)", "MultipleOf(-0.5)", "MultipleOf(1/3)", "Currency(ABC)", "Currency(usd)", "Currency(USD,EUR)",
+		} {
+			if _, err := definitions.ConvertToAnnotation(annoStr); err == nil {
+				t.Fatal("unexpected result", annoStr)
+			}
+		}
+	})
+}
+
+type payment struct {
+	Amount   json.Number `json:"amount" annotation:"@Positive @Currency(USD)"`
+	Fee      float64     `json:"fee" annotation:"@Digits(3,2)"`
+	Tick     json.Number `json:"tick" annotation:"@MultipleOf(0.05)"`
+	Rate     string      `json:"rate" annotation:"@Scale(4)"`
+	Currency string      `json:"currency" annotation:"@Currency"`
+	Price    *string     `json:"price" annotation:"@Currency"`
+}
+
+func TestDecimalFields(t *testing.T) {
+	jsonx.Close()
+
+	if err := jsonx.Check[payment](); err != nil {
+		t.Fatal(err)
+	}
+
+	data := `{"amount": 10.05, "fee": 1.25, "tick": 0.15, "rate": "0.0425", "currency": "USD", "price": "19.99 EUR"}`
+	p, err := jsonx.Unmarshal[payment]([]byte(data))
+	if err != nil {
+		t.Fatal("unexpected result1", err)
+	}
+	if p.Amount != "10.05" || *p.Price != "19.99 EUR" {
+		t.Fatal("unexpected result2", p)
+	}
+
+	for i, tc := range []struct {
+		data  string
+		field string
+	}{
+		{data: strings.Replace(data, `"amount": 10.05`, `"amount": 10.005`, 1), field: "amount"},
+		{data: strings.Replace(data, `"fee": 1.25`, `"fee": 1000`, 1), field: "fee"},
+		{data: strings.Replace(data, `"tick": 0.15`, `"tick": 0.16`, 1), field: "tick"},
+		{data: strings.Replace(data, `"rate": "0.0425"`, `"rate": "0.04255"`, 1), field: "rate"},
+		{data: strings.Replace(data, `"currency": "USD"`, `"currency": "XYZ"`, 1), field: "currency"},
+		{data: strings.Replace(data, `"price": "19.99 EUR"`, `"price": "19.999 EUR"`, 1), field: "price"},
+	} {
+		var validationErr *errors.ValidationError
+		_, err := jsonx.Unmarshal[payment]([]byte(tc.data))
+		if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field {
+			t.Fatal("unexpected result3", i, err)
+		}
+	}
+
+	_, err = jsonx.Unmarshal[payment]([]byte(strings.Replace(data, `"amount": 10.05`, `"amount": 10.005`, 1)))
+	if message := jsonx.Message(err, "en"); message != "amount must be a valid currency amount (USD)" {
+		t.Fatal("unexpected result4", message)
+	}
+
+	schema, err := jsonx.Schema[payment]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := schema["properties"].(map[string]any)
+	assertJSONEqual(t, properties["amount"], `{"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.01}`)
+	assertJSONEqual(t, properties["fee"], `{"type": "number", "multipleOf": 0.01}`)
+	assertJSONEqual(t, properties["tick"], `{"type": "number", "multipleOf": 0.05}`)
+	assertJSONEqual(t, properties["rate"], `{"type": "string"}`)
+	assertJSONEqual(t, properties["currency"], `{"type": "string"}`)
+
+	buf := &bytes.Buffer{}
+	if err := jsonx.WriteTypeScript(buf, reflect.TypeOf(payment{})); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"  amount: z.number().positive().multipleOf(0.01),\n",
+		"  fee: z.number().multipleOf(0.01).gt(-1e3).lt(1e3),\n",
+		"  tick: z.number().multipleOf(0.05),\n",
+		"  rate: z.string(),\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatal("unexpected result5", expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"github.com/aivyss/jsonx"
+	"reflect"
+	"testing"
+)
+
+type describePaging struct {
+	Page int `json:"page" annotation:"@Min(1)"`
+}
+
+type describeNode struct {
+	Name string        `json:"name" annotation:"@NotBlank"`
+	Next *describeNode `json:"next"`
+}
+
+type describeRequest struct {
+	describePaging
+	Name   string       `json:"name" annotation:"@NotBlank @Length(1,50)" fieldErr:"Length=nameTooLong,nameInvalid" doc:"display name"`
+	Code   string       `json:"code" pattern:"^[A-Z]{3}$" default:"ABC"`
+	Node   describeNode `json:"node"`
+	Secret string       `json:"-" annotation:"@NotEmpty"`
+	hidden string
+}
+
+func TestDescribe(t *testing.T) {
+	jsonx.Close()
+	validator1 := orderedValidator1(1)
+	validator2 := orderedValidator2(1)
+	jsonx.RegisterOrderedValidator[testStruct](&validator2)
+	jsonx.RegisterOrderedValidator[testStruct](&validator1)
+	jsonx.RegisterValidator[testStruct](&testStructValidator{})
+
+	t.Run("[fields]", func(t *testing.T) {
+		description, err := jsonx.Describe[describeRequest]()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := &jsonx.TypeDescription{
+			Type: "test.describeRequest",
+			Fields: []jsonx.FieldDescription{
+				{
+					Name: "Page", JSONName: "page", Path: "page", Type: "int",
+					Annotations: []jsonx.AnnotationDescription{{Name: "Min", Args: []string{"1"}, Params: map[string]string{"min": "1"}}},
+				},
+				{
+					Name: "Name", JSONName: "name", Path: "name", Type: "string",
+					Annotations: []jsonx.AnnotationDescription{
+						{Name: "NotBlank"},
+						{Name: "Length", Args: []string{"1", "50"}, Params: map[string]string{"min": "1", "max": "50"}},
+					},
+					FieldErrors: map[string]string{"Length": "nameTooLong"},
+					FieldError:  "nameInvalid",
+					Doc:         "display name",
+				},
+				{Name: "Code", JSONName: "code", Path: "code", Type: "string", Pattern: "^[A-Z]{3}$", Default: "ABC"},
+				{
+					Name: "Node", JSONName: "node", Path: "node", Type: "test.describeNode",
+					Children: []jsonx.FieldDescription{
+						{Name: "Name", JSONName: "name", Path: "node.name", Type: "string", Annotations: []jsonx.AnnotationDescription{{Name: "NotBlank"}}},
+						{Name: "Next", JSONName: "next", Path: "node.next", Type: "*test.describeNode", Recursive: true},
+					},
+				},
+				{Name: "Secret", Path: "Secret", Type: "string", Annotations: []jsonx.AnnotationDescription{{Name: "NotEmpty"}}},
+			},
+		}
+
+		if !reflect.DeepEqual(description, expected) {
+			t.Fatalf("unexpected result1\n%+v", description)
+		}
+	})
+
+	t.Run("[validators]", func(t *testing.T) {
+		description, err := jsonx.Describe[testStruct]()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if description.Validator != "*test.testStructValidator" {
+			t.Fatal("unexpected result1", description.Validator)
+		}
+		expected := []jsonx.ValidatorDescription{
+			{Type: "*test.orderedValidator1", Order: 1},
+			{Type: "*test.orderedValidator2", Order: 2},
+		}
+		if !reflect.DeepEqual(description.OrderedValidators, expected) {
+			t.Fatal("unexpected result2", description.OrderedValidators)
+		}
+	})
+
+	t.Run("[wrong annotation]", func(t *testing.T) {
+		type wrong struct {
+			Name string `json:"name" annotation:"@Unknown"`
+		}
+		if _, err := jsonx.Describe[wrong](); err == nil {
+			t.Fatal("unexpected result1")
+		}
+		if _, err := jsonx.Describe[int](); err == nil {
+			t.Fatal("unexpected result2")
+		}
+	})
+
+	jsonx.Close()
+}
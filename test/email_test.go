@@ -0,0 +1,190 @@
+package test
+
+import (
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"strings"
+	"testing"
+)
+
+var emailCases = []formatCase{
+	{
+		annotation: "Email",
+		valid: []string{
+			"test@example.com", "test@sub.example.co.kr", "first.last+tag@example.com",
+			"!#$%&'*+-/=?^_`{|}~@example.com", `"john doe"@example.com`, `"a\"b"@example.com`,
+			`"a@b"@example.com`, "user@localhost", "user@[192.0.2.1]", "user@[IPv6:2001:db8::1]",
+			"user@xn--bcher-kva.example", strings.Repeat("a", 64) + "@example.com",
+		},
+		invalid: map[string]string{
+			"":                                 "empty value",
+			"example.com":                      "missing '@'",
+			"@example.com":                     "empty local part",
+			"user@":                            "empty domain",
+			".user@example.com":                `local part ".user" has an empty atom`,
+			"us..er@example.com":               `local part "us..er" has an empty atom`,
+			"user.@example.com":                `local part "user." has an empty atom`,
+			"us er@example.com":                "invalid character ' ' in local part",
+			"us(er@example.com":                "invalid character '(' in local part",
+			`"unterminated@example.com`:        "unterminated quoted local part",
+			`"a"b"@example.com`:                `unescaped '"' in quoted local part`,
+			"user@-example.com":                `label "-example" starts or ends with a hyphen`,
+			"user@example..com":                `empty label in "example..com"`,
+			"user@exa_mple.com":                `invalid character '_' in label "exa_mple"`,
+			"user@192.0.2.1":                   `top-level label "1" is numeric`,
+			"user@[192.0.2.256]":               `invalid address literal "[192.0.2.256]"`,
+			"user@[2001:db8::1]":               `invalid address literal "[2001:db8::1]"`,
+			"user@[192.0.2.1":                  `unterminated address literal "[192.0.2.1"`,
+			"jörg@example.com":                 "non-ASCII character 'ö' in local part requires allowIDN",
+			"user@bücher.example":              "non-ASCII character 'ü' in domain requires allowIDN",
+			strings.Repeat("a", 65) + "@x.com": "local part is longer than 64 octets",
+			"a@" + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 63) + "." + strings.Repeat("e", 61): "address is longer than 254 characters",
+		},
+	},
+	{
+		annotation: "Email(strict)",
+		valid:      []string{"test@example.com", "a.b-c@mail.example.org"},
+		invalid: map[string]string{
+			`"john doe"@example.com`: "quoted local part is not allowed",
+			"user@[192.0.2.1]":       "address literal is not allowed",
+			"user@localhost":         `domain "localhost" has no top-level domain`,
+		},
+	},
+	{
+		annotation: "Email(allowIDN)",
+		valid: []string{
+			"jörg@bücher.example", "用户@例子.广告", "θσερ@ελληνικά.gr", "user@xn--bcher-kva.example",
+		},
+		invalid: map[string]string{
+			"user@bü_cher.example": `invalid internationalized domain "bü_cher.example"`,
+			"us er@bücher.example": "invalid character ' ' in local part",
+			"user@́bücher.example": `idna: invalid label`,
+		},
+	},
+	{
+		annotation: "Email(maxLength=20)",
+		valid:      []string{"a@example.com", "abcdefgh@example.com"},
+		invalid: map[string]string{
+			"abcdefghi@example.com": "address is longer than 20 characters",
+		},
+	},
+	{
+		annotation: "Email(allow=example.com, allow=example.org, deny=spam.example.com)",
+		valid:      []string{"a@example.com", "a@mail.example.com", "a@EXAMPLE.ORG"},
+		invalid: map[string]string{
+			"a@example.net":         `domain "example.net" is not allowed`,
+			"a@notexample.com":      `domain "notexample.com" is not allowed`,
+			"a@spam.example.com":    `domain "spam.example.com" is denied`,
+			"a@eu.spam.example.com": `domain "eu.spam.example.com" is denied`,
+		},
+	},
+	{
+		annotation: "Email(allowIDN, deny=bücher.example)",
+		valid:      []string{"a@example.com"},
+		invalid: map[string]string{
+			"a@bücher.example":         `domain "xn--bcher-kva.example" is denied`,
+			"a@XN--BCHER-KVA.example":  `domain "xn--bcher-kva.example" is denied`,
+			"a@münchen.bücher.example": `domain "xn--mnchen-3ya.xn--bcher-kva.example" is denied`,
+		},
+	},
+}
+
+func TestEmail(t *testing.T) {
+	jsonx.Close()
+
+	for _, tc := range emailCases {
+		annotation, err := definitions.ConvertToAnnotation(tc.annotation)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("["+tc.annotation+"]", func(t *testing.T) {
+			for _, value := range tc.valid {
+				if err := annotation.Validate(value); err != nil {
+					t.Fatal("unexpected result1", value, err)
+				}
+				if err := annotation.Validate(&value); err != nil {
+					t.Fatal("unexpected result2", value, err)
+				}
+			}
+
+			for value, reason := range tc.invalid {
+				err := annotation.Validate(value)
+				if err == nil || !strings.Contains(err.Error(), reason) {
+					t.Fatal("unexpected result3", value, err)
+				}
+			}
+
+			if err := annotation.Validate((*string)(nil)); err != nil {
+				t.Fatal("unexpected result4", err)
+			}
+			if err := annotation.Validate(1); err == nil {
+				t.Fatal("unexpected result5")
+			}
+		})
+	}
+
+	t.Run("[wrong arguments]", func(t *testing.T) {
+		for _, annoStr := range []string{
+			"Email(lenient)", "Email(maxLength=0)", "Email(maxLength=255)", "Email(maxLength=a)",
+			"Email(allow=)", "Email(allow=-example.com)", "Email(deny=exa_mple.com)",
+		} {
+			if _, err := definitions.ConvertToAnnotation(annoStr); err == nil {
+				t.Fatal("unexpected result", annoStr)
+			}
+		}
+	})
+}
+
+func TestEmailElements(t *testing.T) {
+	jsonx.Close()
+
+	type request struct {
+		To  []string          `json:"to" annotation:"@Email(strict)"`
+		Cc  map[string]string `json:"cc" annotation:"@Email"`
+		Bcc []*string         `json:"bcc" annotation:"@Email(allow=example.com)"`
+	}
+	if err := jsonx.Check[request](); err != nil {
+		t.Fatal("unexpected result1", err)
+	}
+
+	valid := request{
+		To:  []string{"a@example.com", "b@example.org"},
+		Cc:  map[string]string{"kim": "kim@example.com"},
+		Bcc: []*string{nil},
+	}
+	if err := jsonx.Validate(valid); err != nil {
+		t.Fatal("unexpected result2", err)
+	}
+
+	other := "x@other.com"
+	for i, tc := range []struct {
+		request request
+		field   string
+		value   any
+	}{
+		{request: request{To: []string{"a@example.com", "b@localhost"}}, field: "to[1]", value: "b@localhost"},
+		{request: request{Cc: map[string]string{"kim": "kim"}}, field: "cc.kim", value: "kim"},
+		{request: request{Bcc: []*string{&other}}, field: "bcc[0]", value: &other},
+	} {
+		var validationErr *errors.ValidationError
+		err := jsonx.Validate(tc.request)
+		if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field || validationErr.Value != tc.value {
+			t.Fatal("unexpected result3", i, err)
+		}
+	}
+
+	type options struct {
+		Contact string   `json:"contact" annotation:"@Email(allowIDN, maxLength=100)"`
+		Admins  []string `json:"admins" annotation:"@Email"`
+	}
+	schema, err := jsonx.Schema[options]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := schema["properties"].(map[string]any)
+	assertJSONEqual(t, properties["contact"], `{"type": "string", "format": "idn-email", "maxLength": 100}`)
+	assertJSONEqual(t, properties["admins"], `{"type": ["array", "null"], "items": {"type": "string", "format": "email"}}`)
+}
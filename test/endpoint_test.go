@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/aivyss/jsonx"
 	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/jsonxtest"
 	"github.com/aivyss/typex/pointer"
 	"strings"
 	"testing"
@@ -472,6 +473,7 @@ func TestAnnotation(t *testing.T) {
 
 	t.Run("[Future]", func(t *testing.T) {
 		present := time.Now()
+		jsonxtest.UseClock(t, present)
 		future := present.Add(1 * time.Second)
 		past := future.Add(-2 * time.Second)
 		type testStruct struct {
@@ -508,6 +510,7 @@ func TestAnnotation(t *testing.T) {
 
 	t.Run("[Present]", func(t *testing.T) {
 		present := time.Now()
+		jsonxtest.UseClock(t, present)
 		future := present.Add(1 * time.Second)
 		past := future.Add(-2 * time.Second)
 		type testStruct struct {
@@ -547,6 +550,7 @@ func TestAnnotation(t *testing.T) {
 
 	t.Run("[Past]", func(t *testing.T) {
 		present := time.Now()
+		jsonxtest.UseClock(t, present)
 		future := present.Add(1 * time.Second)
 		past := present.Add(-1 * time.Second)
 		type testStruct struct {
@@ -586,6 +590,7 @@ func TestAnnotation(t *testing.T) {
 
 	t.Run("[FutureOrPresent]", func(t *testing.T) {
 		present := time.Now()
+		jsonxtest.UseClock(t, present)
 		future := present.Add(1 * time.Second)
 		past := present.Add(-1 * time.Second)
 		type testStruct struct {
@@ -625,6 +630,7 @@ func TestAnnotation(t *testing.T) {
 
 	t.Run("[PastOrPresent]", func(t *testing.T) {
 		present := time.Now()
+		jsonxtest.UseClock(t, present)
 		future := present.Add(1 * time.Second)
 		past := present.Add(-1 * time.Second)
 		type testStruct struct {
@@ -726,6 +732,7 @@ func TestValidateFunction(t *testing.T) {
 	}
 
 	present := time.Now()
+	jsonxtest.UseClock(t, present)
 	future := present.Add(1 * time.Second)
 	past := future.Add(-2 * time.Second)
 	type testStruct2 struct {
@@ -756,6 +763,7 @@ func TestFixFieldErr(t *testing.T) {
 	msg := "test_msg"
 	jsonx.RegisterFieldError(errName, msg)
 	present := time.Now()
+	jsonxtest.UseClock(t, present)
 	future := present.Add(1 * time.Second)
 	past := future.Add(-2 * time.Second)
 	type testStruct struct {
@@ -791,6 +799,7 @@ func TestFixFieldErr(t *testing.T) {
 func TestNestedStruct(t *testing.T) {
 	t.Run("[time]", func(t *testing.T) {
 		present := time.Now()
+		jsonxtest.UseClock(t, present)
 		future := present.Add(1 * time.Second)
 		past := present.Add(-1 * time.Second)
 		type testStruct1 struct {
@@ -892,4 +901,109 @@ func TestNestedStruct(t *testing.T) {
 			t.Fatal("unexpected result4")
 		}
 	})
+	t.Run("fields after nested struct", func(t *testing.T) {
+		type testStruct struct {
+			Value string `json:"value" annotation:"@NotBlank"`
+		}
+
+		type testStruct2 struct {
+			Nested  *testStruct `json:"nested"`
+			Value   testStruct  `json:"value"`
+			Trailer string      `json:"trailer" annotation:"@NotBlank"`
+		}
+
+		_, err := jsonx.Unmarshal[testStruct2]([]byte(`{ "value": { "value": "a" }, "trailer": "" }`))
+		if err == nil {
+			t.Fatal("unexpected result1")
+		}
+
+		_, err = jsonx.Unmarshal[testStruct2]([]byte(`{ "value": { "value": "a" }, "trailer": "b" }`))
+		if err != nil {
+			t.Fatal("unexpected result2")
+		}
+
+		_, err = jsonx.Unmarshal[testStruct2]([]byte(`{ "nested": { "value": "a" }, "value": { "value": "a" }, "trailer": "" }`))
+		if err == nil {
+			t.Fatal("unexpected result3")
+		}
+	})
+	t.Run("annotations on nested struct fields", func(t *testing.T) {
+		type testStruct struct {
+			Value string `json:"value" annotation:"@NotBlank"`
+		}
+
+		type testStruct2 struct {
+			Nested *testStruct `json:"nested" annotation:"@Required"`
+		}
+
+		_, err := jsonx.Unmarshal[testStruct2]([]byte(`{}`))
+		var validationErr *jsonxErr.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Annotation != "Required" || validationErr.Field != "nested" {
+			t.Fatal("unexpected result1", err)
+		}
+
+		_, err = jsonx.Unmarshal[testStruct2]([]byte(`{ "nested": { "value": "" } }`))
+		if !errors.As(err, &validationErr) || validationErr.Annotation != "NotBlank" || validationErr.Field != "nested.value" {
+			t.Fatal("unexpected result2", err)
+		}
+
+		if _, err := jsonx.Unmarshal[testStruct2]([]byte(`{ "nested": { "value": "a" } }`)); err != nil {
+			t.Fatal("unexpected result3", err)
+		}
+	})
+}
+
+func TestFieldErrMapping(t *testing.T) {
+	jsonx.Close()
+	jsonx.RegisterFieldError("nameRequired", "name is required")
+	jsonx.RegisterFieldError("nameTooLong", "name is too long")
+	jsonx.RegisterFieldError("nameFormat", "name has a wrong format")
+	jsonx.RegisterFieldError("nameInvalid", "name is invalid")
+	type testStruct struct {
+		Name *string `json:"name" annotation:"@NotBlank @Length(1,5)" pattern:"^[a-z ]*$" fieldErr:"NotBlank=nameRequired,Length=nameTooLong,pattern=nameFormat"`
+	}
+	type testStruct2 struct {
+		Name string `json:"name" annotation:"@NotBlank @Length(1,5)" fieldErr:"Length=nameTooLong,nameInvalid"`
+	}
+
+	if err := jsonx.Check[testStruct](); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, c := range []struct {
+		json    string
+		errName string
+	}{
+		{json: `{ "name": null }`, errName: "nameRequired"},
+		{json: `{ "name": "  " }`, errName: "nameRequired"},
+		{json: `{ "name": "abcdefg" }`, errName: "nameTooLong"},
+		{json: `{ "name": "ABC" }`, errName: "nameFormat"},
+	} {
+		_, err := jsonx.Unmarshal[testStruct]([]byte(c.json))
+		fieldErr, ok := err.(*jsonxErr.FieldError)
+		if !ok || fieldErr.Name() != c.errName {
+			t.Fatal("unexpected result", i, err)
+		}
+
+		var validationErr *jsonxErr.ValidationError
+		if !errors.As(errors.Unwrap(err), &validationErr) || validationErr.Field != "name" {
+			t.Fatal("cause is not reachable", i)
+		}
+	}
+
+	_, err := jsonx.Unmarshal[testStruct2]([]byte(`{ "name": "" }`))
+	if fieldErr, ok := err.(*jsonxErr.FieldError); !ok || fieldErr.Name() != "nameInvalid" {
+		t.Fatal("unexpected result5")
+	}
+	_, err = jsonx.Unmarshal[testStruct2]([]byte(`{ "name": "abcdef" }`))
+	if fieldErr, ok := err.(*jsonxErr.FieldError); !ok || fieldErr.Name() != "nameTooLong" {
+		t.Fatal("unexpected result6")
+	}
+
+	type wrongMapping struct {
+		Name string `json:"name" annotation:"@NotBlank" fieldErr:"Email=nameFormat,nameA,nameB"`
+	}
+	if err := jsonx.Check[wrongMapping](); err == nil {
+		t.Fatal("unexpected result7")
+	}
 }
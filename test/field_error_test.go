@@ -1,8 +1,11 @@
 package test
 
 import (
+	"encoding/json"
+	stdErrors "errors"
 	"github.com/aivyss/jsonx"
 	"github.com/aivyss/jsonx/errors"
+	"strings"
 	"testing"
 )
 
@@ -35,3 +38,79 @@ func TestFieldError(t *testing.T) {
 		t.Fatal("unexpected result4")
 	}
 }
+
+func TestFieldErrorOptions(t *testing.T) {
+	jsonx.Close()
+	sentinel := jsonx.RegisterFieldError(
+		"nameTooLong",
+		"{field} must be at most {max} characters",
+		errors.WithCode("NAME_TOO_LONG"),
+		errors.WithStatus(422),
+		errors.WithParams(map[string]string{"hint": "shorten it"}),
+	)
+	type testStruct struct {
+		Name string `json:"name" annotation:"@Length(1,3)" fieldErr:"nameTooLong"`
+	}
+
+	if sentinel.Code() != "NAME_TOO_LONG" || sentinel.Status() != 422 || sentinel.Field() != "" {
+		t.Fatal("unexpected result1")
+	}
+	if errors.NewFieldErr("plain", "msg").Code() != "plain" {
+		t.Fatal("unexpected result2")
+	}
+
+	err := jsonx.Validate(testStruct{Name: "abcd"})
+	if !stdErrors.Is(err, sentinel) {
+		t.Fatal("unexpected result3")
+	}
+	if stdErrors.Is(err, errors.NewFieldErr("other", "msg")) {
+		t.Fatal("unexpected result4")
+	}
+
+	var fieldErr *errors.FieldError
+	if !stdErrors.As(err, &fieldErr) {
+		t.Fatal("unexpected result5")
+	}
+	if fieldErr == sentinel || fieldErr.Field() != "name" || fieldErr.Msg() != "name must be at most 3 characters" {
+		t.Fatal("unexpected result6", fieldErr.Msg())
+	}
+	if fieldErr.Params()["hint"] != "shorten it" || sentinel.Params()["field"] != "" {
+		t.Fatal("unexpected result7")
+	}
+
+	var validationErr *errors.ValidationError
+	if !stdErrors.As(stdErrors.Unwrap(err), &validationErr) || validationErr.Annotation != "Length" {
+		t.Fatal("unexpected result8")
+	}
+
+	j, marshalErr := json.Marshal(map[string]any{"error": fieldErr})
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	decoded := struct {
+		Error struct {
+			Name   string            `json:"errorName"`
+			Code   string            `json:"code"`
+			Status int               `json:"status"`
+			Field  string            `json:"field"`
+			Params map[string]string `json:"params"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal(j, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Error.Name != "nameTooLong" || decoded.Error.Code != "NAME_TOO_LONG" || decoded.Error.Status != 422 ||
+		decoded.Error.Field != "name" || decoded.Error.Params["max"] != "3" {
+		t.Fatal("unexpected result9", string(j))
+	}
+
+	plain := errors.NewFieldErr("plain", "msg")
+	if plain.Code() != "plain" || strings.Contains(plain.Error(), `"code"`) {
+		t.Fatal("unexpected result10", plain.Error())
+	}
+
+	branded := errors.NewFieldErr("plain", "msg", errors.WithFramework("shop"))
+	if !strings.Contains(branded.Error(), `"framework":"shop"`) || !strings.Contains(plain.Error(), `"framework":"jsonx"`) {
+		t.Fatal("unexpected result11", branded.Error())
+	}
+}
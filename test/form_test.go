@@ -0,0 +1,175 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/aivyss/jsonx"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/jsonxhttp"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type uploadReq struct {
+	Title  string        `form:"title" annotation:"@NotBlank"`
+	Avatar *jsonx.File   `form:"avatar" annotation:"@Required @MaxBytes(1KB) @ContentType(image/png,image/jpeg)"`
+	Docs   []*jsonx.File `form:"doc" annotation:"@MaxBytes(16) @ContentType(text/*)"`
+}
+
+type part struct {
+	name, filename, contentType, content string
+}
+
+func newMultipartRequest(t *testing.T, parts ...part) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for _, p := range parts {
+		if p.filename == "" {
+			if err := writer.WriteField(p.name, p.content); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="`+p.name+`"; filename="`+p.filename+`"`)
+		header.Set("Content-Type", p.contentType)
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(p.content))
+	}
+	_ = writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return r
+}
+
+func TestMultipartBind(t *testing.T) {
+	jsonx.Close()
+	if err := jsonx.Check[uploadReq](); err != nil {
+		t.Fatal(err)
+	}
+
+	png := part{name: "avatar", filename: "a.png", contentType: "image/png", content: "\x89PNG\r\n\x1a\n"}
+	req, err := jsonxhttp.Bind[uploadReq](newMultipartRequest(t,
+		part{name: "title", content: "hello"},
+		png,
+		part{name: "doc", filename: "a.txt", contentType: "text/plain", content: "a"},
+		part{name: "doc", filename: "b.csv", contentType: "text/csv; charset=utf-8", content: "b,c"},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Title != "hello" || req.Avatar.Filename() != "a.png" || req.Avatar.Size() != 8 || len(req.Docs) != 2 {
+		t.Fatal("unexpected result1", req)
+	}
+	if req.Docs[1].ContentType() != "text/csv" {
+		t.Fatal("unexpected result2")
+	}
+	if detected, err := req.Avatar.DetectContentType(); err != nil || detected != "image/png" {
+		t.Fatal("unexpected result3", detected)
+	}
+
+	empty, err := jsonxhttp.Bind[uploadReq](newMultipartRequest(t, part{name: "title", content: "a"}, png, part{name: "doc", filename: "a.txt", contentType: "text/plain"}))
+	if err != nil || empty.Docs[0].Size() != 0 {
+		t.Fatal("unexpected result5", err)
+	}
+
+	for i, c := range []struct {
+		parts      []part
+		annotation string
+	}{
+		{parts: []part{{name: "title", content: "a"}}, annotation: "Required"},
+		{parts: []part{{name: "title", content: "a"}, {name: "avatar", filename: "a.gif", contentType: "image/gif", content: "GIF89a"}}, annotation: "ContentType"},
+		{parts: []part{{name: "title", content: "a"}, {name: "avatar", filename: "a.png", contentType: "image/png", content: strings.Repeat("a", 1025)}}, annotation: "MaxBytes"},
+		{parts: []part{{name: "title", content: "a"}, png, {name: "doc", filename: "a.txt", contentType: "text/plain", content: strings.Repeat("a", 17)}}, annotation: "MaxBytes"},
+		{parts: []part{{name: "title", content: "a"}, png, {name: "doc", filename: "a.bin", contentType: "application/octet-stream", content: "a"}}, annotation: "ContentType"},
+		{parts: []part{{name: "title", content: "a"}, {name: "avatar", filename: "a.png", contentType: "image/png", content: "<script>alert(1)</script>"}}, annotation: "ContentType"},
+		{parts: []part{{name: "title", content: "a"}, png, {name: "doc", filename: "a.txt", contentType: "text/plain", content: "\x89PNG\r\n\x1a\n"}}, annotation: "ContentType"},
+	} {
+		_, err := jsonxhttp.Bind[uploadReq](newMultipartRequest(t, c.parts...))
+		var validationErr *jsonxErr.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Annotation != c.annotation {
+			t.Fatal("unexpected result", i, err)
+		}
+	}
+
+	_, err = jsonxhttp.Bind[uploadReq](newMultipartRequest(t, part{name: "title", content: "a"}, png), jsonxhttp.WithMaxBodyBytes(64))
+	if !errors.Is(err, jsonxhttp.ErrBodyTooLarge) {
+		t.Fatal("unexpected result4", err)
+	}
+}
+
+type attachment struct {
+	File    *jsonx.File `form:"file"`
+	Caption string      `form:"caption"`
+}
+
+type postReq struct {
+	Title      string `form:"title"`
+	Cover      attachment
+	Attachment *attachment
+	Reply      *postReq
+}
+
+func TestMultipartNested(t *testing.T) {
+	jsonx.Close()
+
+	req, err := jsonxhttp.Bind[postReq](newMultipartRequest(t,
+		part{name: "title", content: "hello"},
+		part{name: "caption", content: "a cat"},
+		part{name: "file", filename: "a.txt", contentType: "text/plain", content: "a"},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Cover.File == nil || req.Attachment == nil || req.Attachment.File == nil || req.Attachment.File.Filename() != "a.txt" {
+		t.Fatal("unexpected result1", req)
+	}
+	if req.Attachment.Caption != "a cat" || req.Reply != nil {
+		t.Fatal("unexpected result2", req)
+	}
+
+	req, err = jsonxhttp.Bind[postReq](newMultipartRequest(t, part{name: "title", content: "hello"}))
+	if err != nil || req.Attachment != nil || req.Cover.File != nil {
+		t.Fatal("unexpected result3", req, err)
+	}
+}
+
+func TestFormBind(t *testing.T) {
+	jsonx.Close()
+	type formReq struct {
+		Name  string   `form:"name" annotation:"@NotBlank"`
+		Age   int      `form:"age" annotation:"@Positive"`
+		Roles []string `form:"role" annotation:"@Length(1,2)"`
+	}
+
+	form := url.Values{"name": {"a"}, "age": {"3"}, "role": {"x", "y"}}
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	req, err := jsonxhttp.Bind[formReq](r)
+	if err != nil || req.Name != "a" || req.Age != 3 || len(req.Roles) != 2 {
+		t.Fatal("unexpected result1", err)
+	}
+
+	form.Set("age", "0")
+	if _, err := jsonx.BindForm[formReq](form); err == nil {
+		t.Fatal("unexpected result2")
+	}
+
+	form.Set("age", "x")
+	if _, err := jsonx.BindForm[formReq](form); !errors.Is(err, jsonxErr.ErrUnmarshal) {
+		t.Fatal("unexpected result3")
+	}
+}
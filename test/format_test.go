@@ -0,0 +1,228 @@
+package test
+
+import (
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type formatCase struct {
+	annotation string
+	valid      []string
+	invalid    map[string]string
+}
+
+var identifierFormatCases = []formatCase{
+	{
+		annotation: "UUID",
+		valid: []string{
+			"123e4567-e89b-12d3-a456-426614174000",
+			"00000000-0000-0000-0000-000000000000",
+			"A987FBC9-4BED-3078-CF07-9141BA07C9F3",
+		},
+		invalid: map[string]string{
+			"123e4567e89b12d3a456426614174000":       "wrong length 32",
+			"123e4567-e89b-12d3-a456_426614174000":   "expected '-' at position 23",
+			"123e4567-e89b-12d3-a456-42661417400g":   "invalid character 'g' at position 35",
+			"{123e4567-e89b-12d3-a456-426614174000}": "wrong length 38",
+		},
+	},
+	{
+		annotation: "UUID(4,7)",
+		valid: []string{
+			"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		},
+		invalid: map[string]string{
+			"123e4567-e89b-12d3-a456-426614174000": "version 1 is not allowed",
+			"f47ac10b-58cc-4372-c567-0e02b2c3d479": "not an RFC 9562 variant",
+		},
+	},
+	{
+		annotation: "ULID",
+		valid:      []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "01arz3ndektsv4rrffq69g5fav"},
+		invalid: map[string]string{
+			"01ARZ3NDEKTSV4RRFFQ69G5FA":  "wrong length 25",
+			"01ARZ3NDEKTSV4RRFFQ69G5FAU": "invalid character 'U' at position 25",
+			"01ARZ3NDEKTSV4RRFFQ69G5FIL": "invalid character 'I' at position 24",
+			"8ZZZZZZZZZZZZZZZZZZZZZZZZZ": "timestamp overflow",
+		},
+	},
+	{
+		annotation: "Hex",
+		valid:      []string{"0", "deadBEEF", "0123456789abcdef"},
+		invalid: map[string]string{
+			"":        "empty value",
+			"0xff":    "invalid character 'x' at position 1",
+			"abc def": "invalid character ' ' at position 3",
+		},
+	},
+	{
+		annotation: "Base64",
+		valid:      []string{"aGVsbG8=", "aGVsbG8gd29ybGQ=", "YQ==", "+/+/"},
+		invalid: map[string]string{
+			"":         "empty value",
+			"aGVsbG8":  "invalid std base64",
+			"aGVs*G8=": "invalid std base64 at position 4",
+			"-_-_":     "invalid std base64 at position 0",
+			"YR==":     "invalid std base64",
+		},
+	},
+	{
+		annotation: "Base64(url)",
+		valid:      []string{"aGVsbG8", "aGVsbG8=", "-_-_", "YQ"},
+		invalid: map[string]string{
+			"+/+/":     "invalid url base64 at position 0",
+			"aGVs*G8=": "invalid url base64 at position 4",
+		},
+	},
+	{
+		annotation: "Semver",
+		valid: []string{
+			"0.0.0", "1.2.3", "10.20.30", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-0.3.7",
+			"1.0.0-x.7.z.92", "1.0.0-x-y-z.--", "1.0.0-alpha+001", "1.0.0+20130313144700",
+			"1.0.0-beta+exp.sha.5114f85", "1.0.0+21AF26D3----117B344092BD",
+		},
+		invalid: map[string]string{
+			"1.2":            "expected MAJOR.MINOR.PATCH",
+			"v1.2.3":         `major version "v1" is not a number`,
+			"01.2.3":         `major version "01" has a leading zero`,
+			"1.2.3-01":       `pre-release "01" has a leading zero`,
+			"1.2.3-alpha..1": "pre-release has an empty identifier",
+			"1.2.3+":         "build metadata has an empty identifier",
+			"1.2.3-al_pha":   `pre-release identifier "al_pha" has invalid character '_'`,
+			"1.2.3.4":        "expected MAJOR.MINOR.PATCH",
+		},
+	},
+}
+
+func TestIdentifierFormats(t *testing.T) {
+	jsonx.Close()
+
+	for _, tc := range identifierFormatCases {
+		annotation, err := definitions.ConvertToAnnotation(tc.annotation)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("["+tc.annotation+"]", func(t *testing.T) {
+			for _, value := range tc.valid {
+				if err := annotation.Validate(value); err != nil {
+					t.Fatal("unexpected result1", value, err)
+				}
+				if err := annotation.Validate(&value); err != nil {
+					t.Fatal("unexpected result2", value, err)
+				}
+			}
+
+			for value, reason := range tc.invalid {
+				err := annotation.Validate(value)
+				if err == nil || !strings.Contains(err.Error(), reason) {
+					t.Fatal("unexpected result3", value, err)
+				}
+			}
+
+			if err := annotation.Validate((*string)(nil)); err != nil {
+				t.Fatal("unexpected result4", err)
+			}
+			if err := annotation.Validate(1); err == nil {
+				t.Fatal("unexpected result5")
+			}
+		})
+	}
+
+	t.Run("[wrong arguments]", func(t *testing.T) {
+		for _, annoStr := range []string{"UUID(9)", "UUID(v4)", "Base64(hex)", "Base64(std,url)", "Hex(1)"} {
+			if _, err := definitions.ConvertToAnnotation(annoStr); err == nil {
+				t.Fatal("unexpected result", annoStr)
+			}
+		}
+	})
+}
+
+func TestFormatSchemaPatterns(t *testing.T) {
+	jsonx.Close()
+
+	type formats struct {
+		ULID      string `json:"ULID" annotation:"@ULID"`
+		Hex       string `json:"Hex" annotation:"@Hex"`
+		Base64    string `json:"Base64" annotation:"@Base64"`
+		Base64URL string `json:"Base64(url)" annotation:"@Base64(url)"`
+		Semver    string `json:"Semver" annotation:"@Semver"`
+	}
+	schema, err := jsonx.Schema[formats]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := schema["properties"].(map[string]any)
+
+	// the generated patterns agree with the annotations
+	for _, tc := range identifierFormatCases {
+		property, ok := properties[tc.annotation].(map[string]any)
+		if !ok {
+			continue
+		}
+		pattern := regexp.MustCompile(property["pattern"].(string))
+
+		for _, value := range tc.valid {
+			if !pattern.MatchString(value) {
+				t.Fatal("unexpected result1", tc.annotation, value)
+			}
+		}
+		for value := range tc.invalid {
+			if value != "" && pattern.MatchString(value) {
+				t.Fatal("unexpected result2", tc.annotation, value)
+			}
+		}
+	}
+}
+
+func TestElementFormats(t *testing.T) {
+	jsonx.Close()
+
+	type request struct {
+		IDs      []string          `json:"ids" annotation:"@UUID(4)"`
+		Versions map[string]string `json:"versions" annotation:"@Semver"`
+		Hashes   []*string         `json:"hashes" annotation:"@Hex"`
+	}
+	hash := "zz"
+	valid := request{
+		IDs:      []string{"f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+		Versions: map[string]string{"api": "1.2.3"},
+		Hashes:   []*string{nil},
+	}
+
+	if err := jsonx.Validate(valid); err != nil {
+		t.Fatal("unexpected result1", err)
+	}
+	if err := jsonx.Check[request](); err != nil {
+		t.Fatal("unexpected result2", err)
+	}
+
+	for i, tc := range []struct {
+		request request
+		field   string
+		value   any
+	}{
+		{request: request{IDs: []string{valid.IDs[0], "nope"}}, field: "ids[1]", value: "nope"},
+		{request: request{Versions: map[string]string{"api": "1.2"}}, field: "versions.api", value: "1.2"},
+		{request: request{Hashes: []*string{nil, &hash}}, field: "hashes[1]", value: &hash},
+	} {
+		var validationErr *errors.ValidationError
+		err := jsonx.Validate(tc.request)
+		if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field || validationErr.Value != tc.value {
+			t.Fatal("unexpected result", i, err)
+		}
+	}
+
+	type wrong struct {
+		Counts []int `json:"counts" annotation:"@Hex"`
+	}
+	if err := jsonx.Check[wrong](); err == nil {
+		t.Fatal("unexpected result3")
+	}
+}
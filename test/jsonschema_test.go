@@ -0,0 +1,277 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/aivyss/jsonx"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/jsonschema"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type suiteGroup struct {
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+	Tests       []struct {
+		Description string          `json:"description"`
+		Data        json.RawMessage `json:"data"`
+		Valid       bool            `json:"valid"`
+	} `json:"tests"`
+}
+
+// skippedGroups
+// groups that need remote references, $id based resolution or unevaluatedProperties
+var skippedGroups = map[string]bool{
+	"ref.json/remote ref, containing refs itself":              true,
+	"ref.json/Recursive references between schemas":            true,
+	"ref.json/refs with relative uris and defs":                true,
+	"ref.json/relative refs with absolute uris and defs":       true,
+	"ref.json/ref creates new scope when adjacent to keywords": true,
+}
+
+func TestJSONSchemaSuite(t *testing.T) {
+	for dir, options := range map[string][]jsonschema.Option{
+		"draft7":       {jsonschema.WithDraft(jsonschema.Draft7)},
+		"draft2020-12": nil,
+	} {
+		files, err := filepath.Glob("testdata/jsonschema/" + dir + "/*.json")
+		if err != nil || len(files) == 0 {
+			t.Fatal("no test suite files", dir, err)
+		}
+
+		t.Run("["+dir+"]", func(t *testing.T) {
+			runSuite(t, files, options...)
+		})
+	}
+}
+
+func runSuite(t *testing.T, files []string, options ...jsonschema.Option) {
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var groups []suiteGroup
+		if err := json.Unmarshal(content, &groups); err != nil {
+			t.Fatal(file, err)
+		}
+
+		for _, group := range groups {
+			name := filepath.Base(file) + "/" + group.Description
+			if skippedGroups[name] {
+				continue
+			}
+
+			t.Run(name, func(t *testing.T) {
+				schema, err := jsonschema.Compile(group.Schema, options...)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, test := range group.Tests {
+					err := schema.Validate(test.Data)
+					if test.Valid != (err == nil) {
+						t.Errorf("%s: valid=%v, err=%v", test.Description, test.Valid, err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Run("[structured errors]", func(t *testing.T) {
+		schema := jsonschema.MustCompile([]byte(`{
+			"type": "object",
+			"required": ["name", "tags"],
+			"properties": {
+				"name": {"type": "string", "minLength": 2},
+				"tags": {"type": "array", "items": {"type": "string", "pattern": "^[a-z]+$"}},
+				"price": {"type": "number", "multipleOf": 0.01}
+			},
+			"additionalProperties": false
+		}`))
+
+		err := schema.Validate([]byte(`{"name": "a", "tags": ["ok", "NG"], "price": 1.005, "extra": 1}`))
+		if err == nil {
+			t.Fatal("unexpected result1")
+		}
+
+		violations := map[string]*jsonxErr.ValidationError{}
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var validationErr *jsonxErr.ValidationError
+			if !errors.As(e, &validationErr) {
+				t.Fatal("unexpected result2")
+			}
+			violations[validationErr.Field] = validationErr
+		}
+		if len(violations) != 4 {
+			t.Fatal("unexpected result3", err)
+		}
+		if v := violations["name"]; v == nil || v.Annotation != "minLength" || v.Params["min"] != "2" {
+			t.Fatal("unexpected result4")
+		}
+		if v := violations["tags[1]"]; v == nil || v.Annotation != "pattern" || v.Value != "NG" {
+			t.Fatal("unexpected result5")
+		}
+		if v := violations["price"]; v == nil || v.Annotation != "multipleOf" || v.Params["multipleOf"] != "0.01" {
+			t.Fatal("unexpected result6")
+		}
+		if v := violations["extra"]; v == nil || v.Annotation != "additionalProperties" {
+			t.Fatal("unexpected result7")
+		}
+
+		if err := schema.Validate([]byte(`{"name": "ab", "tags": [], "price": 1.25}`)); err != nil {
+			t.Fatal("unexpected result8", err)
+		}
+	})
+
+	t.Run("[required]", func(t *testing.T) {
+		schema := jsonschema.MustCompile([]byte(`{"properties": {"a": {"required": ["b"]}}}`))
+
+		var validationErr *jsonxErr.ValidationError
+		err := schema.Validate([]byte(`{"a": {}}`))
+		if !errors.As(err, &validationErr) || validationErr.Field != "a.b" || validationErr.Annotation != "required" {
+			t.Fatal("unexpected result1", err)
+		}
+	})
+
+	t.Run("[draft 2020-12]", func(t *testing.T) {
+		schema := jsonschema.MustCompile([]byte(`{
+			"$defs": {
+				"node": {
+					"type": "object",
+					"properties": {"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}}
+				},
+				"positive": {"exclusiveMinimum": 0}
+			},
+			"type": "array",
+			"prefixItems": [{"$ref": "#/$defs/node"}, {"$ref": "#/$defs/positive", "type": "integer"}],
+			"items": false
+		}`))
+
+		if err := schema.Validate([]byte(`[{"children": [{"children": []}]}, 1]`)); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+		if err := schema.Validate([]byte(`[{"children": [{"children": 1}]}, 1]`)); err == nil {
+			t.Fatal("unexpected result2")
+		}
+		// $ref applies together with its siblings
+		if err := schema.Validate([]byte(`[{}, 1.5]`)); err == nil {
+			t.Fatal("unexpected result3")
+		}
+		if err := schema.Validate([]byte(`[{}, 0]`)); err == nil {
+			t.Fatal("unexpected result4")
+		}
+		if err := schema.Validate([]byte(`[{}, 1, 2]`)); err == nil {
+			t.Fatal("unexpected result5")
+		}
+	})
+
+	t.Run("[go values]", func(t *testing.T) {
+		schema := jsonschema.MustCompile([]byte(`{
+			"type": "object",
+			"required": ["name", "count", "tags"],
+			"properties": {
+				"name": {"type": "string"},
+				"count": {"type": "integer", "minimum": 1},
+				"price": {"type": "number", "multipleOf": 0.01},
+				"ratio": {"type": "number", "maximum": 0.1},
+				"tags": {"type": "array", "items": {"type": "string", "pattern": "^[a-z]+$"}, "maxItems": 2},
+				"scores": {"type": "object", "additionalProperties": {"type": "integer"}},
+				"items": {"type": "array", "items": {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}}
+			}
+		}`))
+
+		valid := map[string]any{
+			"name":   "ab",
+			"count":  3,
+			"price":  1.25,
+			"ratio":  float32(0.1),
+			"tags":   []string{"a", "b"},
+			"scores": map[string]int{"math": 90},
+			"items":  []map[string]any{{"id": uint8(1)}, {"id": int64(2)}},
+		}
+		if err := schema.ValidateValue(valid); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+
+		for i, tc := range []struct {
+			key        string
+			value      any
+			field      string
+			annotation string
+		}{
+			{key: "count", value: 0, field: "count", annotation: "minimum"},
+			{key: "count", value: 1.5, field: "count", annotation: "type"},
+			{key: "price", value: 1.005, field: "price", annotation: "multipleOf"},
+			{key: "tags", value: []string{"a", "B"}, field: "tags[1]", annotation: "pattern"},
+			{key: "tags", value: []string{"a", "b", "c"}, field: "tags", annotation: "maxItems"},
+			{key: "scores", value: map[string]float64{"math": 90.5}, field: "scores.math", annotation: "type"},
+			{key: "items", value: []map[string]any{{"id": 1}, {}}, field: "items[1].id", annotation: "required"},
+		} {
+			value := map[string]any{}
+			for k, v := range valid {
+				value[k] = v
+			}
+			value[tc.key] = tc.value
+
+			var validationErr *jsonxErr.ValidationError
+			err := schema.ValidateValue(value)
+			if !errors.As(err, &validationErr) || validationErr.Field != tc.field || validationErr.Annotation != tc.annotation {
+				t.Fatal("unexpected result2", i, err)
+			}
+		}
+
+		if err := schema.ValidateValue(map[string]any{"name": "ab", "count": 1}); err == nil {
+			t.Fatal("unexpected result3")
+		}
+		if err := jsonschema.MustCompile([]byte(`{"type": "array", "items": {"type": "integer"}}`)).ValidateValue([]int{1, 2}); err != nil {
+			t.Fatal("unexpected result4", err)
+		}
+	})
+
+	t.Run("[compile errors]", func(t *testing.T) {
+		for i, document := range []string{
+			`{"type": "text"}`,
+			`{"pattern": "("}`,
+			`{"minLength": -1}`,
+			`{"$ref": "#/$defs/missing"}`,
+			`{"$ref": "https://example.com/schema.json"}`,
+			`{"properties": {"a": 1}}`,
+			`not json`,
+		} {
+			if _, err := jsonschema.Compile([]byte(document)); err == nil {
+				t.Fatal("unexpected result", i)
+			}
+		}
+	})
+
+	t.Run("[malformed payload]", func(t *testing.T) {
+		schema := jsonschema.MustCompile([]byte(`true`))
+		if err := schema.Validate([]byte(`{`)); !errors.Is(err, jsonxErr.ErrUnmarshal) {
+			t.Fatal("unexpected result1")
+		}
+	})
+
+	t.Run("[generated schema]", func(t *testing.T) {
+		jsonx.Close()
+		generated, err := jsonx.Schema[schemaAddress]()
+		if err != nil {
+			t.Fatal(err)
+		}
+		document, _ := json.Marshal(generated)
+		schema := jsonschema.MustCompile(document)
+
+		if err := schema.Validate([]byte(`{"street": "main", "zip": "12345"}`)); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+		err = schema.Validate([]byte(`{"street": "main", "zip": "1234"}`))
+		if err == nil || !strings.Contains(err.Error(), "zip") {
+			t.Fatal("unexpected result2", err)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/aivyss/jsonx"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/jsonxhttp"
+	"github.com/aivyss/jsonx/problem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type createUserReq struct {
+	Name string `json:"name" annotation:"@NotBlank"`
+	Age  int    `json:"age" annotation:"@Positive"`
+}
+
+func newJSONRequest(body, contentType string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+
+	return r
+}
+
+func TestBind(t *testing.T) {
+	jsonx.Close()
+
+	req, err := jsonxhttp.Bind[createUserReq](newJSONRequest(`{ "name": "a", "age": 1 }`, "application/json; charset=utf-8"))
+	if err != nil || req.Name != "a" {
+		t.Fatal("unexpected result1")
+	}
+
+	_, err = jsonxhttp.Bind[createUserReq](newJSONRequest(`{ "name": "a", "age": 1 }`, "text/plain"))
+	if !errors.Is(err, jsonxhttp.ErrUnsupportedMediaType) {
+		t.Fatal("unexpected result2")
+	}
+
+	_, err = jsonxhttp.Bind[createUserReq](newJSONRequest(`{ "name": "abcdefgh", "age": 1 }`, "application/merge-patch+json"), jsonxhttp.WithMaxBodyBytes(10))
+	if !errors.Is(err, jsonxhttp.ErrBodyTooLarge) {
+		t.Fatal("unexpected result3")
+	}
+
+	_, err = jsonxhttp.Bind[createUserReq](newJSONRequest(`{ "name": "", "age": 1 }`, "application/json"))
+	var validationErr *jsonxErr.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatal("unexpected result4")
+	}
+}
+
+func TestHandle(t *testing.T) {
+	jsonx.Close()
+	handler := jsonxhttp.Handle(func(ctx context.Context, req *createUserReq) (any, error) {
+		switch req.Name {
+		case "empty":
+			return nil, nil
+		case "taken":
+			return nil, jsonxErr.NewFieldErr("nameTaken", "name is taken", jsonxErr.WithStatus(http.StatusConflict)).WithField("name")
+		case "boom":
+			return nil, errors.New("database is down")
+		}
+
+		return map[string]string{"name": req.Name}, nil
+	}, jsonxhttp.WithMaxBodyBytes(64), jsonxhttp.WithSuccessStatus(http.StatusCreated))
+
+	serve := func(body, contentType string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newJSONRequest(body, contentType))
+
+		return recorder
+	}
+
+	cases := []struct {
+		body        string
+		contentType string
+		status      int
+	}{
+		{body: `{ "name": "a", "age": 1 }`, contentType: "application/json", status: 201},
+		{body: `{ "name": "empty", "age": 1 }`, contentType: "application/json", status: 204},
+		{body: `{ "name": "a", "age": 1 }`, contentType: "text/plain", status: 415},
+		{body: `{ "name": "` + strings.Repeat("a", 64) + `", "age": 1 }`, contentType: "application/json", status: 413},
+		{body: `{ "name": `, contentType: "application/json", status: 400},
+		{body: `{ "name": "a", "age": 0 }`, contentType: "application/json", status: 422},
+		{body: `{ "name": "taken", "age": 1 }`, contentType: "application/json", status: 409},
+		{body: `{ "name": "boom", "age": 1 }`, contentType: "application/json", status: 500},
+	}
+
+	for i, c := range cases {
+		recorder := serve(c.body, c.contentType)
+		if recorder.Code != c.status {
+			t.Fatal("unexpected status", i, recorder.Code, recorder.Body.String())
+		}
+		if c.status >= 400 && recorder.Header().Get("Content-Type") != problem.ContentType {
+			t.Fatal("unexpected content type", i)
+		}
+	}
+
+	recorder := serve(`{ "name": "a", "age": -1 }`, "application/json")
+	details := problem.Details{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &details); err != nil {
+		t.Fatal(err)
+	}
+	if len(details.Errors) != 1 || details.Errors[0].Field != "age" || details.Errors[0].Annotation != "Positive" {
+		t.Fatal("unexpected result", details)
+	}
+
+	if body := serve(`{ "name": "boom", "age": 1 }`, "application/json").Body.String(); strings.Contains(body, "database") {
+		t.Fatal("internal error is exposed")
+	}
+}
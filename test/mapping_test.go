@@ -0,0 +1,128 @@
+package test
+
+import (
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/errors"
+	"testing"
+)
+
+type mappingAddress struct {
+	Zip string `json:"zip"`
+}
+
+type mappingUser struct {
+	Name    string         `json:"name" annotation:"@NotBlank @Length(1,50)"`
+	Email   string         `json:"email"`
+	Code    string         `json:"code" annotation:"@Length(1,2)" pattern:"^[A-Z]+$"`
+	Address mappingAddress `json:"address"`
+}
+
+func TestLoadRules(t *testing.T) {
+	valid := mappingUser{Name: "kim", Email: "kim@example.com", Code: "abc", Address: mappingAddress{Zip: "12345"}}
+
+	setUp := func(t *testing.T) {
+		jsonx.Close()
+		jsonx.RegisterFieldError("mappingNameTooLong", "{field} is too long")
+		jsonx.MustRegisterType[mappingUser]()
+		if err := jsonx.LoadRuleFile("testdata/rules/mapping.yaml"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("[merged with tags]", func(t *testing.T) {
+		setUp(t)
+
+		if err := jsonx.Validate(valid); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+
+		v := valid
+		v.Name = "abcdef"
+		var fieldErr *errors.FieldError
+		if err := jsonx.Validate(v); !stdErrors.As(err, &fieldErr) || fieldErr.Name() != "mappingNameTooLong" {
+			t.Fatal("unexpected result2", err)
+		}
+
+		// @NotBlank of the tag is kept
+		v.Name = " "
+		if err := jsonx.Validate(v); err == nil {
+			t.Fatal("unexpected result3")
+		}
+
+		v = valid
+		v.Email = "kim@other.com"
+		if err := jsonx.Validate(v); err == nil {
+			t.Fatal("unexpected result4")
+		}
+
+		v = valid
+		v.Address.Zip = "1234"
+		if err := jsonx.Validate(v); err == nil {
+			t.Fatal("unexpected result5")
+		}
+
+		// replace drops @Length(1,2) and the pattern of the tag
+		v = valid
+		v.Code = "lowercase code"
+		if err := jsonx.Validate(v); err != nil {
+			t.Fatal("unexpected result6", err)
+		}
+	})
+
+	t.Run("[later files override]", func(t *testing.T) {
+		setUp(t)
+		if err := jsonx.LoadRuleFile("testdata/rules/mapping.json"); err != nil {
+			t.Fatal(err)
+		}
+
+		v := valid
+		v.Name = "abcd"
+		if err := jsonx.Validate(v); err == nil {
+			t.Fatal("unexpected result1")
+		}
+
+		description, err := jsonx.Describe[mappingUser]()
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := description.Fields[0]
+		if len(name.Annotations) != 2 || name.Annotations[1].Args[1] != "3" || name.FieldErrors["Length"] != "mappingNameTooLong" {
+			t.Fatal("unexpected result2", name)
+		}
+	})
+
+	t.Run("[precise errors]", func(t *testing.T) {
+		setUp(t)
+
+		for i, tc := range []struct {
+			data  string
+			typ   string
+			field string
+		}{
+			{data: "types: {test.unknownUser: {name: {annotations: '@NotBlank'}}}", typ: "test.unknownUser"},
+			{data: "types: {mappingUser: {nmae: {annotations: '@NotBlank'}}}", typ: "mappingUser", field: "nmae"},
+			{data: "types: {mappingUser: {name: {annotations: '@Unknown'}}}", typ: "mappingUser", field: "name"},
+			{data: "types: {mappingUser: {name: {pattern: '('}}}", typ: "mappingUser", field: "name"},
+			{data: "types: {mappingUser: {name: {fieldErr: 'Length='}}}", typ: "mappingUser", field: "name"},
+			{data: "types: {mappingAddress: {zip: {annotations: '@Positive'}}}", typ: "mappingAddress", field: "zip"},
+		} {
+			err := jsonx.LoadRules([]byte(tc.data))
+			var mappingErr *errors.MappingError
+			if !stdErrors.As(err, &mappingErr) || mappingErr.Type != tc.typ || mappingErr.Field != tc.field {
+				t.Fatal("unexpected result", i, err)
+			}
+		}
+
+		if err := jsonx.LoadRules([]byte("types: {mappingUser: {name: {annotation: '@NotBlank'}}}")); err == nil {
+			t.Fatal("unexpected result7")
+		}
+
+		// nothing was applied by the failed loads
+		if err := jsonx.Validate(valid); err != nil {
+			t.Fatal("unexpected result8", err)
+		}
+	})
+
+	jsonx.Close()
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/message"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMessage(t *testing.T) {
+	type testStruct struct {
+		Name  string `json:"name" annotation:"@NotBlank"`
+		Count int    `json:"count" annotation:"@Min(3)"`
+	}
+
+	t.Run("[built-in bundles]", func(t *testing.T) {
+		jsonx.Close()
+		for _, name := range definitions.DefaultAnnotationNames() {
+			for _, locale := range []string{"en", "ko"} {
+				if _, ok := message.Lookup("@"+name, locale); !ok {
+					t.Fatalf("no %s message for @%s", locale, name)
+				}
+			}
+		}
+	})
+
+	t.Run("[template params]", func(t *testing.T) {
+		jsonx.Close()
+		_, err := jsonx.Unmarshal[testStruct]([]byte(`{ "name": "a", "count": 1 }`))
+
+		var validationErr *jsonxErr.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatal("unexpected result1")
+		}
+		if validationErr.Field != "count" || validationErr.Annotation != "Min" || validationErr.Params["min"] != "3" {
+			t.Fatal("unexpected result2")
+		}
+
+		if msg := jsonx.Message(err, ""); msg != "count must be greater than or equal to 3" {
+			t.Fatal("unexpected result3", msg)
+		}
+		if msg := jsonx.Message(err, "ko-KR,ko;q=0.9,en;q=0.8"); msg != "count은(는) 3 이상이어야 합니다" {
+			t.Fatal("unexpected result4", msg)
+		}
+
+		jsonx.RegisterMessages("en", map[string]string{"@Min": "{annotation}: {field}={value} < {min}"})
+		if msg := jsonx.Message(err, "fr"); msg != "Min: count=1 < 3" {
+			t.Fatal("unexpected result5", msg)
+		}
+	})
+
+	t.Run("[field error template]", func(t *testing.T) {
+		jsonx.Close()
+		jsonx.RegisterFieldError("nameErr", "{field} is wrong")
+		type testStruct struct {
+			Name string `json:"name" annotation:"@NotBlank" fieldErr:"nameErr"`
+		}
+
+		err := jsonx.Validate(testStruct{Name: " "})
+		if msg := jsonx.Message(err, "ko"); msg != "name is wrong" {
+			t.Fatal("unexpected result1", msg)
+		}
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "ko.json"), []byte(`{ "nameErr": "{field} 오류" }`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := jsonx.LoadMessageBundles(dir); err != nil {
+			t.Fatal(err)
+		}
+
+		if msg := jsonx.Message(err, "ko-KR"); msg != "name 오류" {
+			t.Fatal("unexpected result2", msg)
+		}
+		if msg := jsonx.Message(err, "en"); msg != "name is wrong" {
+			t.Fatal("unexpected result3", msg)
+		}
+	})
+
+	t.Run("[invalid bundle]", func(t *testing.T) {
+		jsonx.Close()
+		path := filepath.Join(t.TempDir(), "en.json")
+		if err := os.WriteFile(path, []byte(`[1, 2]`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := jsonx.LoadMessageBundle("en", path); err == nil {
+			t.Fatal("unexpected result")
+		}
+	})
+}
+
+func TestRangeAnnotation(t *testing.T) {
+	type testStruct struct {
+		Min    int      `json:"min" annotation:"@Min(1)"`
+		Max    *float64 `json:"max" annotation:"@Max(10.5)"`
+		Length string   `json:"length" annotation:"@NotBlank @Length(2, 4)"`
+		Tags   []string `json:"tags" annotation:"@Length(0,1)"`
+	}
+
+	_, err := jsonx.Unmarshal[testStruct]([]byte(`{ "min": 1, "max": 10.5, "length": "한국어", "tags": [] }`))
+	if err != nil {
+		t.Fatal("unexpected result1", err)
+	}
+
+	for i, j := range []string{
+		`{ "min": 0, "max": 1, "length": "ab" }`,
+		`{ "min": 1, "max": 11, "length": "ab" }`,
+		`{ "min": 1, "max": null, "length": "ab" }`,
+		`{ "min": 1, "max": 1, "length": "abcde" }`,
+		`{ "min": 1, "max": 1, "length": "ab", "tags": ["a", "b"] }`,
+	} {
+		if _, err := jsonx.Unmarshal[testStruct]([]byte(j)); err == nil {
+			t.Fatal("unexpected result", i)
+		}
+	}
+
+	type wrongArgs struct {
+		Value int `json:"value" annotation:"@Min(a) @Length(3,1) @Positive(1)"`
+	}
+	if err := jsonx.Check[wrongArgs](); err == nil {
+		t.Fatal("unexpected result2")
+	}
+}
@@ -0,0 +1,301 @@
+package test
+
+import (
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var networkFormatCases = []formatCase{
+	{
+		annotation: "URL",
+		valid: []string{
+			"https://example.com", "http://example.com:8080/a/b?c=d#e", "ftp://user:pw@files.example.com/x",
+			"https://127.0.0.1", "https://[::1]:443/", "HTTPS://EXAMPLE.COM",
+		},
+		invalid: map[string]string{
+			"":                        "empty value",
+			"example.com":             "missing scheme",
+			"/relative/path":          "missing scheme",
+			"mailto:a@example.com":    "missing host",
+			"https://exa mple.com":    "invalid character ' ' at position 11",
+			"https://-example.com":    `label "-example" starts or ends with a hyphen`,
+			"https://example.com:0":   "port 0 is out of range",
+			"https://example.com:080": `invalid port "080"`,
+			"https://example.com:":    "empty port",
+			"https://[::g]/":          "invalid",
+			"https://exa_mple.com/":   `invalid character '_' in label "exa_mple"`,
+			"https://example.com%zz/": "invalid",
+		},
+	},
+	{
+		annotation: "URL(https)",
+		valid:      []string{"https://example.com", "HTTPS://example.com/path"},
+		invalid: map[string]string{
+			"http://example.com":       `scheme "http" is not allowed`,
+			"javascript://example.com": `scheme "javascript" is not allowed`,
+		},
+	},
+	{
+		annotation: "URI",
+		valid: []string{
+			"https://example.com/a", "mailto:a@example.com", "urn:isbn:0451450523", "tel:+1-816-555-1212",
+		},
+		invalid: map[string]string{
+			"":              "empty value",
+			"//example.com": "missing scheme",
+			"1http://x":     "missing scheme",
+			"https://a b":   "invalid character ' ' at position 9",
+			"https://x/%zz": "invalid URL escape",
+			"urn:isbn:\t1":  `invalid character '\t' at position 9`,
+		},
+	},
+	{
+		annotation: "Hostname",
+		valid:      []string{"localhost", "example.com", "a-b.example.com", "1password.com", "xn--bcher-kva.example"},
+		invalid: map[string]string{
+			"":                                "empty host",
+			"example..com":                    `empty label in "example..com"`,
+			"example.com.":                    `empty label in "example.com."`,
+			"-example.com":                    `label "-example" starts or ends with a hyphen`,
+			"exa_mple.com":                    `invalid character '_' in label "exa_mple"`,
+			"192.168.0.1":                     `top-level label "1" is numeric`,
+			strings.Repeat("a", 64) + ".com":  "is longer than 63 characters",
+			strings.Repeat("a.", 127) + "com": "host is longer than 253 characters",
+		},
+	},
+	{
+		annotation: "IP",
+		valid:      []string{"192.168.0.1", "8.8.8.8", "::1", "2001:db8::1", "::ffff:10.0.0.1", "fe80::1%eth0"},
+		invalid: map[string]string{
+			"":             `invalid address ""`,
+			"256.0.0.1":    `invalid address "256.0.0.1"`,
+			"1.2.3":        `invalid address "1.2.3"`,
+			"010.0.0.1":    `invalid address "010.0.0.1"`,
+			"example.com":  `invalid address "example.com"`,
+			"2001:db8:::1": `invalid address "2001:db8:::1"`,
+		},
+	},
+	{
+		annotation: "IPv4",
+		valid:      []string{"0.0.0.0", "192.168.0.1", "255.255.255.255"},
+		invalid: map[string]string{
+			"::1":             `wrong address family "::1"`,
+			"::ffff:10.0.0.1": `wrong address family "::ffff:10.0.0.1"`,
+			"1.2.3.4/8":       `invalid address "1.2.3.4/8"`,
+		},
+	},
+	{
+		annotation: "IPv6",
+		valid:      []string{"::", "::1", "2001:db8::1", "::ffff:10.0.0.1"},
+		invalid: map[string]string{
+			"10.0.0.1": `wrong address family "10.0.0.1"`,
+		},
+	},
+	{
+		annotation: "IP(public)",
+		valid:      []string{"8.8.8.8", "2606:4700:4700::1111", "1.1.1.1"},
+		invalid: map[string]string{
+			"127.0.0.1":        "loopback address 127.0.0.1 is not allowed",
+			"::1":              "loopback address ::1 is not allowed",
+			"::ffff:127.0.0.1": "loopback address 127.0.0.1 is not allowed",
+			"10.1.2.3":         "private address 10.1.2.3 is not allowed",
+			"172.16.0.1":       "private address 172.16.0.1 is not allowed",
+			"192.168.1.1":      "private address 192.168.1.1 is not allowed",
+			"fd00::1":          "private address fd00::1 is not allowed",
+			"169.254.169.254":  "non-public address 169.254.169.254 is not allowed",
+			"fe80::1":          "non-public address fe80::1 is not allowed",
+			"0.0.0.0":          "non-public address 0.0.0.0 is not allowed",
+			"224.0.0.1":        "non-public address 224.0.0.1 is not allowed",
+			"100.64.0.1":       "reserved address 100.64.0.1 is not allowed (100.64.0.0/10)",
+			"2001:db8::1":      "reserved address 2001:db8::1 is not allowed (2001:db8::/32)",
+		},
+	},
+	{
+		annotation: "IPv4(noPrivate,noLoopback)",
+		valid:      []string{"8.8.8.8", "239.1.2.3"},
+		invalid: map[string]string{
+			"10.0.0.1":        "private address 10.0.0.1 is not allowed",
+			"127.0.0.2":       "loopback address 127.0.0.2 is not allowed",
+			"169.254.169.254": "link-local address 169.254.169.254 is not allowed",
+			"224.0.0.251":     "link-local address 224.0.0.251 is not allowed",
+			"0.0.0.0":         "unspecified address 0.0.0.0 is not allowed",
+		},
+	},
+	{
+		annotation: "IP(noPrivate,noLoopback)",
+		valid:      []string{"2606:4700:4700::1111", "ff05::1:3", "64:ff9b::808:808"},
+		invalid: map[string]string{
+			"fe80::1":                "link-local address fe80::1 is not allowed",
+			"ff02::2":                "link-local address ff02::2 is not allowed",
+			"::":                     "unspecified address :: is not allowed",
+			"::ffff:169.254.169.254": "link-local address 169.254.169.254 is not allowed",
+			"64:ff9b::7f00:1":        "loopback address 127.0.0.1 is not allowed, embedded in 64:ff9b::7f00:1",
+			"64:ff9b::a00:1":         "private address 10.0.0.1 is not allowed, embedded in 64:ff9b::a00:1",
+			"64:ff9b::a9fe:a9fe":     "link-local address 169.254.169.254 is not allowed, embedded in 64:ff9b::a9fe:a9fe",
+			"64:ff9b::":              "unspecified address 0.0.0.0 is not allowed, embedded in 64:ff9b::",
+		},
+	},
+	{
+		annotation: "IP(noPrivate)",
+		valid:      []string{"0.0.0.0", "::1", "64:ff9b::7f00:1"},
+		invalid: map[string]string{
+			"169.254.0.1":       "link-local address 169.254.0.1 is not allowed",
+			"64:ff9b::c0a8:101": "private address 192.168.1.1 is not allowed, embedded in 64:ff9b::c0a8:101",
+		},
+	},
+	{
+		annotation: "CIDR",
+		valid:      []string{"10.0.0.0/8", "192.168.1.0/24", "0.0.0.0/0", "192.168.1.1/32", "2001:db8::/32", "::/0"},
+		invalid: map[string]string{
+			"":               `invalid network ""`,
+			"10.0.0.0":       `invalid network "10.0.0.0"`,
+			"10.0.0.0/33":    `invalid network "10.0.0.0/33"`,
+			"10.0.0.1/8":     "host bits are set, the network is 10.0.0.0/8",
+			"2001:db8::1/32": "host bits are set, the network is 2001:db8::/32",
+		},
+	},
+	{
+		annotation: "MAC",
+		valid: []string{
+			"00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E", "001a.2b3c.4d5e", "00:1a:2b:3c:4d:5e:6f:70",
+		},
+		invalid: map[string]string{
+			"":                  `invalid address ""`,
+			"00:1a:2b:3c:4d":    `invalid address "00:1a:2b:3c:4d"`,
+			"00:1a-2b:3c:4d:5e": `invalid address "00:1a-2b:3c:4d:5e"`,
+			"00:1a:2b:3c:4d:zz": `invalid address "00:1a:2b:3c:4d:zz"`,
+			"00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01": "20-byte address is neither EUI-48 nor EUI-64",
+		},
+	},
+	{
+		annotation: "Port",
+		valid:      []string{"1", "80", "8080", "65535"},
+		invalid: map[string]string{
+			"":      `invalid port ""`,
+			"0":     "port 0 is out of range 1-65535",
+			"65536": "port 65536 is out of range 1-65535",
+			"080":   `invalid port "080"`,
+			"-1":    `invalid port "-1"`,
+			"http":  `invalid port "http"`,
+		},
+	},
+}
+
+func TestNetworkFormats(t *testing.T) {
+	jsonx.Close()
+
+	for _, tc := range networkFormatCases {
+		annotation, err := definitions.ConvertToAnnotation(tc.annotation)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("["+tc.annotation+"]", func(t *testing.T) {
+			for _, value := range tc.valid {
+				if err := annotation.Validate(value); err != nil {
+					t.Fatal("unexpected result1", value, err)
+				}
+				if err := annotation.Validate(&value); err != nil {
+					t.Fatal("unexpected result2", value, err)
+				}
+			}
+
+			for value, reason := range tc.invalid {
+				err := annotation.Validate(value)
+				if err == nil || !strings.Contains(err.Error(), reason) {
+					t.Fatal("unexpected result3", value, err)
+				}
+			}
+
+			if err := annotation.Validate((*string)(nil)); err != nil {
+				t.Fatal("unexpected result4", err)
+			}
+		})
+	}
+
+	t.Run("[Port numbers]", func(t *testing.T) {
+		annotation, err := definitions.ConvertToAnnotation("Port")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		port := 443
+		for _, value := range []any{1, int64(65535), &port, (*int)(nil)} {
+			if err := annotation.Validate(value); err != nil {
+				t.Fatal("unexpected result1", value, err)
+			}
+		}
+		for _, value := range []any{0, -1, int32(65536)} {
+			if err := annotation.Validate(value); err == nil {
+				t.Fatal("unexpected result2", value)
+			}
+		}
+	})
+
+	t.Run("[wrong arguments]", func(t *testing.T) {
+		for _, annoStr := range []string{"URL(1http)", "URL(ht tp)", "IP(private)", "IPv4(v6)", "CIDR(1)", "Port(80)"} {
+			if _, err := definitions.ConvertToAnnotation(annoStr); err == nil {
+				t.Fatal("unexpected result", annoStr)
+			}
+		}
+	})
+}
+
+func TestNetworkSchema(t *testing.T) {
+	jsonx.Close()
+
+	type endpoint struct {
+		Callback string   `json:"callback" annotation:"@URL(http,https)"`
+		Docs     string   `json:"docs" annotation:"@URI"`
+		Host     string   `json:"host" annotation:"@Hostname"`
+		Address  string   `json:"address" annotation:"@IP(public)"`
+		Gateway  string   `json:"gateway" annotation:"@IPv4"`
+		Networks []string `json:"networks" annotation:"@CIDR"`
+		MAC      string   `json:"mac" annotation:"@MAC"`
+		Port     int      `json:"port" annotation:"@Port"`
+		Ports    []string `json:"ports" annotation:"@Port"`
+	}
+	if err := jsonx.Check[endpoint](); err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := jsonx.Schema[endpoint]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties := schema["properties"].(map[string]any)
+	for name, expected := range map[string]string{
+		"callback": `{"type": "string", "format": "uri", "pattern": "^(?:[Hh][Tt][Tt][Pp]|[Hh][Tt][Tt][Pp][Ss]):"}`,
+		"docs":     `{"type": "string", "format": "uri"}`,
+		"host":     `{"type": "string", "format": "hostname", "maxLength": 253}`,
+		"address":  `{"type": "string", "oneOf": [{"format": "ipv4"}, {"format": "ipv6"}]}`,
+		"gateway":  `{"type": "string", "format": "ipv4"}`,
+		"port":     `{"type": "integer", "minimum": 1, "maximum": 65535}`,
+	} {
+		assertJSONEqual(t, properties[name], expected)
+	}
+
+	// the generated patterns agree with the annotations
+	for property, name := range map[string]string{"networks": "CIDR", "mac": "MAC", "ports": "Port"} {
+		schema := properties[property].(map[string]any)
+		if items, ok := schema["items"].(map[string]any); ok {
+			schema = items
+		}
+		pattern := regexp.MustCompile(schema["pattern"].(string))
+
+		for _, tc := range networkFormatCases {
+			if tc.annotation != name {
+				continue
+			}
+			for _, value := range tc.valid {
+				if !pattern.MatchString(value) {
+					t.Fatal("unexpected result1", name, value)
+				}
+			}
+		}
+	}
+}
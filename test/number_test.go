@@ -0,0 +1,254 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type cents int64
+
+type level uint8
+
+func TestNumericTypes(t *testing.T) {
+	jsonx.Close()
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	hugePlusOne := new(big.Int).Add(huge, big.NewInt(1))
+	third := big.NewRat(1, 3)
+
+	for annoStr, cases := range map[string]map[any]bool{
+		"Positive": {
+			cents(1): true, cents(-1): false, level(1): true, level(0): false, uint64(math.MaxUint64): true,
+			json.Number("0.000001"): true, json.Number("-1e-9"): false, json.Number("0"): false,
+			huge: true, new(big.Int).Neg(huge): false,
+			big.NewFloat(1e-300): true, big.NewFloat(math.Inf(-1)): false,
+			third: true, new(big.Rat): false,
+			math.Inf(1): true, math.NaN(): false,
+		},
+		"NegativeOrZero": {
+			cents(0): true, level(0): true, level(1): false, json.Number("-0.0"): true, big.NewFloat(0): true,
+		},
+		"Min(123456789012345678901234567890)": {
+			huge: true, new(big.Int).Sub(huge, big.NewInt(1)): false, json.Number("123456789012345678901234567890"): true,
+			json.Number("123456789012345678901234567889.99"): false, uint64(math.MaxUint64): false,
+		},
+		"Max(123456789012345678901234567890)": {
+			huge: true, hugePlusOne: false, big.NewFloat(1e30): false, json.Number("1.2345678901234567890123456789e29"): true,
+		},
+		"Max(9007199254740992)": {
+			int64(9007199254740992): true, int64(9007199254740993): false, uint64(9007199254740993): false,
+		},
+		"Max(0.1)": {
+			float32(0.1): true, 0.1: true, json.Number("0.1000000000000000001"): false, big.NewRat(1, 10): true,
+			math.NaN(): false, json.Number(""): false, json.Number("abc"): false,
+		},
+		"Min(0.5)": {
+			third: false, big.NewRat(1, 2): true, cents(1): true, level(0): false,
+		},
+		"Enum(1,2.5,3)": {
+			cents(1): true, level(3): true, json.Number("2.50"): true, big.NewRat(5, 2): true, json.Number("2"): false, huge: false,
+		},
+	} {
+		annotation, err := definitions.ConvertToAnnotation(annoStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for value, valid := range cases {
+			if err := annotation.Validate(value); valid != (err == nil) {
+				t.Fatal("unexpected result1", annoStr, value, err)
+			}
+		}
+	}
+
+	t.Run("[pointers and nil]", func(t *testing.T) {
+		annotation, err := definitions.ConvertToAnnotation("Positive")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c := cents(5)
+		n := json.Number("5")
+		if err := annotation.Validate(&c); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+		if err := annotation.Validate(&n); err != nil {
+			t.Fatal("unexpected result2", err)
+		}
+
+		var annotationErr *errors.AnnotationError
+		for i, value := range []any{(*cents)(nil), (*json.Number)(nil), (*big.Int)(nil), (*big.Float)(nil)} {
+			if err := annotation.Validate(value); !stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonNil {
+				t.Fatal("unexpected result3", i, err)
+			}
+		}
+		for i, value := range []any{"1", true, []int{1}} {
+			if err := annotation.Validate(value); !stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonWrongType {
+				t.Fatal("unexpected result4", i, err)
+			}
+		}
+		if err := annotation.Validate(json.Number("")); !stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonEmpty {
+			t.Fatal("unexpected result5", err)
+		}
+		if err := annotation.Validate(json.Number("1,5")); !stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonFormat {
+			t.Fatal("unexpected result6", err)
+		}
+	})
+
+	t.Run("[wrong arguments]", func(t *testing.T) {
+		for _, annoStr := range []string{"Min(1/3)", "Min(0x10)", "Max(Inf)", "Max(NaN)", "Min(1_000)", "Length(5,1)", "Min(1e999999)", "Max(1e-1001)"} {
+			if _, err := definitions.ConvertToAnnotation(annoStr); err == nil {
+				t.Fatal("unexpected result", annoStr)
+			}
+		}
+	})
+
+	t.Run("[huge exponents]", func(t *testing.T) {
+		var annotationErr *errors.AnnotationError
+		for _, annoStr := range []string{"Min(0)", "Scale(2)", "Enum(1,2)"} {
+			annotation, err := definitions.ConvertToAnnotation(annoStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			hugeFloat, _, _ := big.ParseFloat("1e999999", 10, 53, big.ToNearestEven)
+			tinyFloat, _, _ := big.ParseFloat("-1e-999999", 10, 53, big.ToNearestEven)
+			for _, value := range []any{json.Number("1e999999"), json.Number("-1e-999999"), json.Number("1" + strings.Repeat("0", 1000)), hugeFloat, tinyFloat} {
+				if err := annotation.Validate(value); !stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonFormat {
+					t.Fatal("unexpected result1", annoStr, err)
+				}
+			}
+			if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+				t.Fatal("unexpected result2", annoStr, elapsed)
+			}
+		}
+
+		annotation, err := definitions.ConvertToAnnotation("Max(1e1000)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := annotation.Validate(json.Number("1e1000")); err != nil {
+			t.Fatal("unexpected result3", err)
+		}
+	})
+}
+
+type account struct {
+	Balance  cents       `json:"balance" annotation:"@PositiveOrZero @Max(1000000)"`
+	Level    level       `json:"level" annotation:"@Min(1) @Max(10)"`
+	Amount   json.Number `json:"amount" annotation:"@Positive"`
+	Supply   *big.Int    `json:"supply" annotation:"@Required @Max(123456789012345678901234567890)"`
+	Rate     *big.Float  `json:"rate" annotation:"@Positive"`
+	Ratio    big.Rat     `json:"ratio" annotation:"@Min(0) @Max(1)"`
+	Port     uint16      `json:"port" annotation:"@Port"`
+	Priority level       `json:"priority" annotation:"@Enum(1,2,3)"`
+}
+
+type quota struct {
+	Amount json.Number `json:"amount" annotation:"@Min(1) @Max(100)"`
+	Count  int         `json:"count" annotation:"@Min(1)"`
+	Port   json.Number `json:"port" annotation:"@Port"`
+}
+
+func TestAbsentNumbers(t *testing.T) {
+	jsonx.Close()
+
+	if _, err := jsonx.Unmarshal[quota]([]byte(`{"amount": 10, "count": 1, "port": 8080}`)); err != nil {
+		t.Fatal("unexpected result1", err)
+	}
+
+	for i, tc := range []struct {
+		data  string
+		field string
+	}{
+		{data: `{"count": 1, "port": 8080}`, field: "amount"},
+		{data: `{"amount": 10, "port": 8080}`, field: "count"},
+		{data: `{"amount": 10, "count": 1}`, field: "port"},
+	} {
+		var validationErr *errors.ValidationError
+		_, err := jsonx.Unmarshal[quota]([]byte(tc.data))
+		if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field {
+			t.Fatal("unexpected result2", i, err)
+		}
+	}
+}
+
+func TestNumericFields(t *testing.T) {
+	jsonx.Close()
+
+	if err := jsonx.Check[account](); err != nil {
+		t.Fatal(err)
+	}
+
+	data := `{"balance": 100, "level": 3, "amount": 0.01, "supply": 99999999999999999999999999,` +
+		` "rate": "1.5", "ratio": "1/3", "port": 8080, "priority": 2}`
+	a, err := jsonx.Unmarshal[account]([]byte(data))
+	if err != nil {
+		t.Fatal("unexpected result1", err)
+	}
+	if a.Amount != "0.01" || a.Supply.String() != "99999999999999999999999999" {
+		t.Fatal("unexpected result2", a)
+	}
+
+	for i, tc := range []struct {
+		data  string
+		field string
+	}{
+		{data: strings.Replace(data, `"balance": 100`, `"balance": 1000001`, 1), field: "balance"},
+		{data: strings.Replace(data, `"level": 3`, `"level": 0`, 1), field: "level"},
+		{data: strings.Replace(data, `"amount": 0.01`, `"amount": 0`, 1), field: "amount"},
+		{data: strings.Replace(data, `99999999999999999999999999`, `123456789012345678901234567891`, 1), field: "supply"},
+		{data: strings.Replace(data, `"rate": "1.5"`, `"rate": "-1.5"`, 1), field: "rate"},
+		{data: strings.Replace(data, `"ratio": "1/3"`, `"ratio": "4/3"`, 1), field: "ratio"},
+		{data: strings.Replace(data, `"port": 8080`, `"port": 0`, 1), field: "port"},
+		{data: strings.Replace(data, `"priority": 2`, `"priority": 4`, 1), field: "priority"},
+	} {
+		var validationErr *errors.ValidationError
+		_, err := jsonx.Unmarshal[account]([]byte(tc.data))
+		if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field {
+			t.Fatal("unexpected result3", i, err)
+		}
+	}
+
+	schema, err := jsonx.Schema[account]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := schema["properties"].(map[string]any)
+	assertJSONEqual(t, properties["balance"], `{"type": "integer", "minimum": 0, "maximum": 1000000}`)
+	assertJSONEqual(t, properties["level"], `{"type": "integer", "minimum": 1, "maximum": 10}`)
+	assertJSONEqual(t, properties["amount"], `{"type": "number", "exclusiveMinimum": 0}`)
+	if j, _ := json.Marshal(properties["supply"]); !strings.Contains(string(j), `"maximum":123456789012345678901234567890`) {
+		t.Fatal("unexpected result4", string(j))
+	}
+	assertJSONEqual(t, properties["rate"], `{"type": "string"}`)
+	assertJSONEqual(t, properties["ratio"], `{"type": "string"}`)
+	assertJSONEqual(t, properties["priority"], `{"type": "integer", "minimum": 0, "enum": [1, 2, 3]}`)
+
+	buf := &bytes.Buffer{}
+	if err := jsonx.WriteTypeScript(buf, reflect.TypeOf(account{})); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"  balance: z.number().int().nonnegative().max(1000000),\n",
+		"  amount: z.number().positive(),\n",
+		"  supply: z.number().int().max(123456789012345678901234567890),\n",
+		"  rate: z.string(),\n",
+		"  ratio: z.string(),\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatal("unexpected result5", expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package test
+
+import (
+	"bytes"
+	"github.com/aivyss/jsonx"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type openAPIAddress struct {
+	Street string `json:"street" annotation:"@NotBlank" doc:"street and number"`
+}
+
+type openAPIOrder struct {
+	ID       int64            `json:"id" annotation:"@Positive"`
+	Status   string           `json:"status" annotation:"@Enum(draft,placed,'on hold')" default:"draft" doc:"order status"`
+	Priority *int             `json:"priority" annotation:"@Enum(1,2,3)" default:"2"`
+	Labels   []string         `json:"labels" default:"[\"new\"]"`
+	Due      time.Time        `json:"due" default:"2024-01-02T03:04:05Z"`
+	Shipping *openAPIAddress  `json:"shipping" doc:"null for pickup"`
+	Billing  openAPIAddress   `json:"billing"`
+	Extra    map[string]int64 `json:"extra"`
+}
+
+func TestOpenAPIComponents(t *testing.T) {
+	jsonx.Close()
+
+	t.Run("[components]", func(t *testing.T) {
+		components, err := jsonx.OpenAPIComponents(reflect.TypeOf(openAPIOrder{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertJSONEqual(t, components, `{
+			"components": {
+				"schemas": {
+					"openAPIOrder": {
+						"type": "object",
+						"properties": {
+							"id": {"type": "integer", "exclusiveMinimum": 0},
+							"status": {"type": "string", "enum": ["draft", "placed", "on hold"], "default": "draft", "description": "order status"},
+							"priority": {"type": "integer", "enum": [1, 2, 3], "default": 2},
+							"labels": {"type": ["array", "null"], "items": {"type": "string"}, "default": ["new"]},
+							"due": {"type": "string", "format": "date-time", "default": "2024-01-02T03:04:05Z"},
+							"shipping": {"anyOf": [{"$ref": "#/components/schemas/openAPIAddress"}, {"type": "null"}], "description": "null for pickup"},
+							"billing": {"$ref": "#/components/schemas/openAPIAddress"},
+							"extra": {"type": ["object", "null"], "additionalProperties": {"type": "integer"}}
+						}
+					},
+					"openAPIAddress": {
+						"type": "object",
+						"required": ["street"],
+						"properties": {
+							"street": {"type": "string", "minLength": 1, "pattern": "\\S", "description": "street and number"}
+						}
+					}
+				}
+			}
+		}`)
+	})
+
+	t.Run("[registered types as yaml]", func(t *testing.T) {
+		jsonx.Close()
+		jsonx.MustRegisterType[openAPIOrder]()
+
+		buf := &bytes.Buffer{}
+		if err := jsonx.WriteOpenAPIComponents(buf, jsonx.OpenAPIYAML); err != nil {
+			t.Fatal(err)
+		}
+
+		var document map[string]any
+		if err := yaml.Unmarshal(buf.Bytes(), &document); err != nil {
+			t.Fatal(err)
+		}
+		schemas := document["components"].(map[string]any)["schemas"].(map[string]any)
+		if _, ok := schemas["openAPIOrder"]; !ok {
+			t.Fatal("unexpected result1")
+		}
+		if _, ok := schemas["openAPIAddress"]; !ok {
+			t.Fatal("unexpected result2")
+		}
+		if !strings.Contains(buf.String(), "$ref: '#/components/schemas/openAPIAddress'") {
+			t.Fatal("unexpected result3", buf.String())
+		}
+	})
+
+	t.Run("[wrong default]", func(t *testing.T) {
+		type wrongDefault struct {
+			Count int `json:"count" default:"many"`
+		}
+		if _, err := jsonx.OpenAPIComponents(reflect.TypeOf(wrongDefault{})); err == nil {
+			t.Fatal("unexpected result1")
+		}
+		if err := jsonx.Check[wrongDefault](); err == nil {
+			t.Fatal("unexpected result2")
+		}
+	})
+
+	t.Run("[not a struct]", func(t *testing.T) {
+		if _, err := jsonx.OpenAPIComponents(reflect.TypeOf("")); err == nil {
+			t.Fatal("unexpected result1")
+		}
+	})
+}
+
+func TestEnumAnnotation(t *testing.T) {
+	jsonx.Close()
+
+	type order struct {
+		Status   string `json:"status" annotation:"@Enum(draft,'on hold')"`
+		Priority *int   `json:"priority" annotation:"@Enum(1,2)"`
+	}
+	two := 2
+	three := 3
+
+	if err := jsonx.Validate(order{Status: "on hold", Priority: &two}); err != nil {
+		t.Fatal("unexpected result1", err)
+	}
+	if err := jsonx.Validate(order{Status: "placed", Priority: &two}); err == nil {
+		t.Fatal("unexpected result2")
+	}
+	if err := jsonx.Validate(order{Status: "draft", Priority: &three}); err == nil {
+		t.Fatal("unexpected result3")
+	}
+	if err := jsonx.Validate(order{Status: "draft"}); err == nil {
+		t.Fatal("unexpected result4")
+	}
+	if err := jsonx.Check[order](); err != nil {
+		t.Fatal("unexpected result5", err)
+	}
+}
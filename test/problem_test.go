@@ -0,0 +1,104 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/aivyss/jsonx"
+	jsonxErr "github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/problem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type problemStruct struct {
+	Name  string `json:"name" annotation:"@NotBlank"`
+	Email string `json:"email" pattern:"^[^@]+@[^@]+$" fieldErr:"emailFormat"`
+}
+
+type problemValidator struct{}
+
+func (v *problemValidator) Validate(p problemStruct) error {
+	if p.Name == "both" {
+		return errors.Join(
+			jsonxErr.NewFieldErr("first", "first failed").WithField("name"),
+			jsonxErr.NewFieldErr("second", "second failed", jsonxErr.WithStatus(409)),
+		)
+	}
+
+	return nil
+}
+
+func TestProblem(t *testing.T) {
+	t.Run("[malformed]", func(t *testing.T) {
+		jsonx.Close()
+		_, err := jsonx.Unmarshal[problemStruct]([]byte(`{ "name": `))
+
+		details := problem.Render(err, "")
+		if details.Status != 400 || details.Type != "about:blank" || details.Title != "Bad Request" || details.Detail == "" {
+			t.Fatal("unexpected result", details)
+		}
+	})
+
+	t.Run("[annotation]", func(t *testing.T) {
+		jsonx.Close()
+		_, err := jsonx.Unmarshal[problemStruct]([]byte(`{ "name": " " }`))
+
+		renderer := problem.NewRenderer(problem.WithTypeBaseURI("https://example.com/problems/"))
+		details := renderer.Render(err, "ko")
+		if details.Status != 422 || details.Type != "https://example.com/problems/validation" || len(details.Errors) != 1 {
+			t.Fatal("unexpected result1", details)
+		}
+
+		issue := details.Errors[0]
+		if issue.Field != "name" || issue.Annotation != "NotBlank" || issue.Message != "name은(는) 공백일 수 없습니다" {
+			t.Fatal("unexpected result2", issue)
+		}
+		if details.Detail != issue.Message {
+			t.Fatal("unexpected result3")
+		}
+	})
+
+	t.Run("[field error]", func(t *testing.T) {
+		jsonx.Close()
+		jsonx.RegisterFieldError("emailFormat", "{field} is not an email", jsonxErr.WithCode("EMAIL_FORMAT"), jsonxErr.WithStatus(400))
+		_, err := jsonx.Unmarshal[problemStruct]([]byte(`{ "name": "a", "email": "wrong" }`))
+
+		renderer := problem.NewRenderer(
+			problem.WithTypeURI("EMAIL_FORMAT", "https://example.com/problems/email"),
+			problem.WithTitle("EMAIL_FORMAT", "Invalid email"),
+		)
+		recorder := httptest.NewRecorder()
+		renderer.Write(recorder, httptest.NewRequest(http.MethodPost, "/", nil), err)
+
+		if recorder.Code != 400 || recorder.Header().Get("Content-Type") != problem.ContentType {
+			t.Fatal("unexpected result1")
+		}
+
+		details := problem.Details{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &details); err != nil {
+			t.Fatal(err)
+		}
+		if details.Type != "https://example.com/problems/email" || details.Title != "Invalid email" || len(details.Errors) != 1 {
+			t.Fatal("unexpected result2", details)
+		}
+		issue := details.Errors[0]
+		if issue.Field != "email" || issue.Code != "EMAIL_FORMAT" || issue.Annotation != "pattern" || issue.Message != "email is not an email" {
+			t.Fatal("unexpected result3", issue)
+		}
+	})
+
+	t.Run("[joined errors]", func(t *testing.T) {
+		jsonx.Close()
+		jsonx.RegisterValidator[problemStruct](&problemValidator{})
+		_, err := jsonx.Unmarshal[problemStruct]([]byte(`{ "name": "both", "email": "a@example.com" }`))
+
+		details := problem.Render(err, "")
+		if details.Status != 409 || len(details.Errors) != 2 || details.Detail != "" {
+			t.Fatal("unexpected result1", details)
+		}
+		if details.Errors[0].Code != "first" || details.Errors[1].Message != "second failed" {
+			t.Fatal("unexpected result2", details)
+		}
+	})
+}
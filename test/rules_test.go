@@ -0,0 +1,165 @@
+package test
+
+import (
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// sdkUser stands for a generated type that can not carry tags
+type sdkUser struct {
+	Name    string
+	Email   string
+	Age     int
+	Code    string `annotation:"@NotBlank @Length(1,2)"`
+	Address sdkAddress
+}
+
+type sdkAddress struct {
+	Street string
+}
+
+func TestRules(t *testing.T) {
+	jsonx.Close()
+	nameErr := jsonx.RegisterFieldError("nameInvalid", "name is invalid")
+	tooLongErr := jsonx.RegisterFieldError("nameTooLong", "{field} must be at most {max} characters")
+
+	jsonx.Rules[sdkUser]().
+		Field("Name").NotBlank().Length(1, 5).FieldErrorFor("Length", "nameTooLong").FieldError("nameInvalid").
+		Field("Email").Email().Pattern(`@example\.com$`).
+		Field("Age").Min("0").Max("150").
+		Field("Code").Length(1, 4)
+	jsonx.Rules[sdkAddress]().Field("Street").NotBlank()
+
+	valid := sdkUser{Name: "kim", Email: "kim@example.com", Age: 20, Code: "ABCD", Address: sdkAddress{Street: "main"}}
+
+	t.Run("[valid]", func(t *testing.T) {
+		if err := jsonx.Validate(valid); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+		if err := jsonx.Check[sdkUser](); err != nil {
+			t.Fatal("unexpected result2", err)
+		}
+	})
+
+	t.Run("[field errors]", func(t *testing.T) {
+		v := valid
+		v.Name = "too long name"
+		err := jsonx.Validate(v)
+		var fieldErr *errors.FieldError
+		if !stdErrors.Is(err, tooLongErr) || !stdErrors.As(err, &fieldErr) || fieldErr.Msg() != "Name must be at most 5 characters" {
+			t.Fatal("unexpected result1", err)
+		}
+
+		v.Name = " "
+		if err := jsonx.Validate(v); !stdErrors.Is(err, nameErr) {
+			t.Fatal("unexpected result2", err)
+		}
+	})
+
+	t.Run("[rules]", func(t *testing.T) {
+		for i, v := range []sdkUser{
+			{Name: "kim", Email: "kim@other.com", Age: 20, Code: "A", Address: sdkAddress{Street: "main"}},
+			{Name: "kim", Email: "kim@example.com", Age: 200, Code: "A", Address: sdkAddress{Street: "main"}},
+			{Name: "kim", Email: "kim@example.com", Age: 20, Code: "ABCDE", Address: sdkAddress{Street: "main"}},
+			{Name: "kim", Email: "kim@example.com", Age: 20, Code: " ", Address: sdkAddress{Street: "main"}},
+			{Name: "kim", Email: "kim@example.com", Age: 20, Code: "A", Address: sdkAddress{Street: " "}},
+		} {
+			if err := jsonx.Validate(v); err == nil {
+				t.Fatal("unexpected result", i)
+			}
+		}
+	})
+
+	t.Run("[quoted arguments]", func(t *testing.T) {
+		type status struct {
+			Value string
+		}
+		jsonx.Rules[status]().Field("Value").Enum("open", "on hold, waiting")
+
+		if err := jsonx.Validate(status{Value: "on hold, waiting"}); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+		if err := jsonx.Validate(status{Value: "on hold"}); err == nil {
+			t.Fatal("unexpected result2")
+		}
+	})
+
+	t.Run("[bounds]", func(t *testing.T) {
+		type limits struct {
+			Supply  *big.Int
+			Timeout time.Duration
+			Start   jsonx.Date
+			Open    jsonx.TimeOfDay
+		}
+		jsonx.Rules[limits]().
+			Field("Supply").Max("123456789012345678901234567890").
+			Field("Timeout").Min("1s").Max("1m30s").
+			Field("Start").Min("2024-01-01").
+			Field("Open").Min("09:00")
+
+		huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		v := limits{Supply: huge, Timeout: time.Minute, Start: jsonx.Date{Year: 2024, Month: time.March, Day: 1}, Open: jsonx.TimeOfDay{Hour: 9}}
+		if err := jsonx.Validate(v); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+
+		for i, invalid := range []limits{
+			{Supply: new(big.Int).Add(huge, big.NewInt(1)), Timeout: v.Timeout, Start: v.Start, Open: v.Open},
+			{Supply: huge, Timeout: 2 * time.Minute, Start: v.Start, Open: v.Open},
+			{Supply: huge, Timeout: v.Timeout, Start: jsonx.Date{Year: 2023, Month: time.December, Day: 31}, Open: v.Open},
+			{Supply: huge, Timeout: v.Timeout, Start: v.Start, Open: jsonx.TimeOfDay{Hour: 8, Minute: 59}},
+		} {
+			if err := jsonx.Validate(invalid); err == nil {
+				t.Fatal("unexpected result2", i)
+			}
+		}
+	})
+
+	t.Run("[single quotes]", func(t *testing.T) {
+		type status struct {
+			Value string
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Fatal("unexpected result")
+			}
+		}()
+		jsonx.Rules[status]().Field("Value").Enum("open", "it's")
+	})
+
+	t.Run("[describe]", func(t *testing.T) {
+		description, err := jsonx.Describe[sdkUser]()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		code := description.Fields[3]
+		if len(code.Annotations) != 2 || code.Annotations[0].Name != "NotBlank" || code.Annotations[1].Args[1] != "4" {
+			t.Fatal("unexpected result1", code.Annotations)
+		}
+	})
+
+	t.Run("[misconfigured rules]", func(t *testing.T) {
+		type other struct {
+			Count int
+		}
+		jsonx.Rules[other]().Field("Count").NotBlank()
+		if err := jsonx.Check[other](); err == nil {
+			t.Fatal("unexpected result1")
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Fatal("unexpected result2")
+			}
+		}()
+		jsonx.Rules[other]().Field("Unknown")
+	})
+
+	jsonx.Close()
+}
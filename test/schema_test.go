@@ -0,0 +1,135 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/aivyss/jsonx"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	Street string `json:"street" annotation:"@NotBlank"`
+	Zip    string `json:"zip" pattern:"^[0-9]{5}$"`
+}
+
+type schemaBase struct {
+	ID int64 `json:"id" annotation:"@Positive"`
+}
+
+type schemaUser struct {
+	schemaBase
+	Name     *string            `json:"name" annotation:"@NotBlank @Length(1,50)"`
+	Email    string             `json:"email" annotation:"@Email"`
+	Age      int                `json:"age,omitempty" annotation:"@Min(0) @Max(150)"`
+	Score    float64            `json:"score" annotation:"@PositiveOrZero"`
+	Tags     []string           `json:"tags" annotation:"@Required @NotContainsBlank"`
+	Birth    *time.Time         `json:"birth" annotation:"@Past"`
+	Home     *schemaAddress     `json:"home"`
+	Work     schemaAddress      `json:"work"`
+	Attrs    map[string]float32 `json:"attrs"`
+	Code     string             `json:"code" annotation:"@Banana2"`
+	Secret   string             `json:"-"`
+	internal string
+}
+
+func TestSchema(t *testing.T) {
+	jsonx.Close()
+	if err := jsonx.RegisterCustomAnnotation("Banana2", func(v any) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	jsonx.RegisterSchemaHook("Banana2", func(args []string) map[string]any {
+		return map[string]any{"const": "banana"}
+	})
+
+	schema, err := jsonx.Schema[schemaUser]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["name", "tags"],
+		"properties": {
+			"id": {"type": "integer", "exclusiveMinimum": 0},
+			"name": {"type": "string", "minLength": 1, "maxLength": 50, "pattern": "\\S"},
+			"email": {"type": "string", "format": "email"},
+			"age": {"type": "integer", "minimum": 0, "maximum": 150},
+			"score": {"type": "number", "minimum": 0},
+			"tags": {"type": "array", "items": {"type": "string", "minLength": 1, "pattern": "\\S"}},
+			"birth": {"type": "string", "format": "date-time"},
+			"home": {"anyOf": [{"$ref": "#/$defs/schemaAddress"}, {"type": "null"}]},
+			"work": {"$ref": "#/$defs/schemaAddress"},
+			"attrs": {"type": ["object", "null"], "additionalProperties": {"type": "number"}},
+			"code": {"type": "string", "const": "banana"}
+		},
+		"$defs": {
+			"schemaAddress": {
+				"type": "object",
+				"required": ["street"],
+				"properties": {
+					"street": {"type": "string", "minLength": 1, "pattern": "\\S"},
+					"zip": {"type": "string", "pattern": "^[0-9]{5}$"}
+				}
+			}
+		}
+	}`
+
+	assertJSONEqual(t, schema, expected)
+
+	type wrong struct {
+		Ch chan int `json:"ch"`
+	}
+	if _, err := jsonx.Schema[wrong](); err == nil {
+		t.Fatal("unexpected result")
+	}
+
+	t.Run("[nil rejected]", func(t *testing.T) {
+		type nullables struct {
+			Nickname *string   `json:"nickname" annotation:"@NotBlank"`
+			Token    *string   `json:"token" annotation:"@Required"`
+			Note     *string   `json:"note" annotation:"@Length(0,10)"`
+			Memo     *string   `json:"memo"`
+			Codes    []string  `json:"codes" annotation:"@NotEmpty"`
+			Scores   []float64 `json:"scores"`
+		}
+
+		schema, err := jsonx.Schema[nullables]()
+		if err != nil {
+			t.Fatal(err)
+		}
+		properties := schema["properties"].(map[string]any)
+		assertJSONEqual(t, properties["nickname"], `{"type": "string", "minLength": 1, "pattern": "\\S"}`)
+		assertJSONEqual(t, properties["token"], `{"type": "string"}`)
+		assertJSONEqual(t, properties["memo"], `{"type": ["string", "null"]}`)
+		assertJSONEqual(t, properties["scores"], `{"type": ["array", "null"], "items": {"type": "number"}}`)
+		for _, name := range []string{"note", "codes"} {
+			if j, _ := json.Marshal(properties[name]); strings.Contains(string(j), `"null"`) {
+				t.Fatal("unexpected result", name, string(j))
+			}
+		}
+	})
+}
+
+func assertJSONEqual(t *testing.T, actual any, expected string) {
+	t.Helper()
+
+	j, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var a, e any
+	if err := json.Unmarshal(j, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(a, e) {
+		t.Fatalf("unexpected json\nactual:   %s\nexpected: %s", j, expected)
+	}
+}
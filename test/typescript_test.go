@@ -0,0 +1,142 @@
+package test
+
+import (
+	"bytes"
+	"github.com/aivyss/jsonx"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type tsAddress struct {
+	Street string `json:"street" annotation:"@NotBlank" doc:"street and number"`
+	Zip    string `json:"zip" pattern:"^[0-9]{5}(/[0-9]{4})?$"`
+}
+
+type tsCategory struct {
+	Name     string        `json:"name"`
+	Children []*tsCategory `json:"children"`
+}
+
+type tsUser struct {
+	ID       int64              `json:"id" annotation:"@Positive"`
+	Name     *string            `json:"name" annotation:"@NotBlank @Length(1,50)"`
+	Nickname *string            `json:"nickname"`
+	Email    string             `json:"email" annotation:"@Email"`
+	Role     string             `json:"role" annotation:"@Enum(admin,member)"`
+	Tags     []string           `json:"tags" annotation:"@NotContainsBlank"`
+	Birth    *time.Time         `json:"birth" annotation:"@Past"`
+	Home     *tsAddress         `json:"home"`
+	Category tsCategory         `json:"category"`
+	Attrs    map[string]float64 `json:"x-attrs"`
+	Secret   string             `json:"-"`
+}
+
+func TestWriteTypeScript(t *testing.T) {
+	jsonx.Close()
+
+	t.Run("[interfaces and schemas]", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := jsonx.WriteTypeScript(buf, reflect.TypeOf(tsUser{})); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `// Code generated by jsonx. DO NOT EDIT.
+
+import { z } from "zod";
+
+export interface TsAddress {
+  /** street and number */
+  street: string;
+  zip: string;
+}
+
+export const TsAddressSchema = z.object({
+  street: z.string().trim().min(1).describe("street and number"),
+  zip: z.string().regex(/^[0-9]{5}(\/[0-9]{4})?$/),
+});
+
+export interface TsCategory {
+  name: string;
+  children: (TsCategory | null)[] | null;
+}
+
+export const TsCategorySchema: z.ZodType<TsCategory> = z.object({
+  name: z.string(),
+  children: z.array(z.lazy(() => TsCategorySchema).nullable()).nullable(),
+});
+
+export interface TsUser {
+  id: number;
+  name: string;
+  nickname?: string | null;
+  email: string;
+  role: "admin" | "member";
+  tags: string[] | null;
+  birth: string;
+  home?: TsAddress | null;
+  category: TsCategory;
+  "x-attrs": Record<string, number> | null;
+}
+
+export const TsUserSchema = z.object({
+  id: z.number().int().positive(),
+  name: z.string().trim().min(1).min(1).max(50),
+  nickname: z.string().nullish(),
+  email: z.string().email(),
+  role: z.enum(["admin", "member"]),
+  tags: z.array(z.string().trim().min(1)).nullable(),
+  birth: z.string().datetime({ offset: true }).refine((v) => new Date(v).getTime() <= Date.now() - 1000, { message: "must be in the past" }),
+  home: TsAddressSchema.nullish(),
+  category: TsCategorySchema,
+  "x-attrs": z.record(z.string(), z.number()).nullable(),
+});
+`
+		if buf.String() != expected {
+			t.Fatalf("unexpected result1\n%s", buf.String())
+		}
+	})
+
+	t.Run("[registered types]", func(t *testing.T) {
+		jsonx.Close()
+		jsonx.MustRegisterType[tsAddress]()
+
+		buf := &bytes.Buffer{}
+		if err := jsonx.WriteTypeScript(buf); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "export const TsAddressSchema = z.object({") {
+			t.Fatal("unexpected result1")
+		}
+	})
+
+	t.Run("[doc comments]", func(t *testing.T) {
+		type commented struct {
+			Note string `json:"note" doc:"ends here */ alert(1) /* and /** nests"`
+		}
+
+		buf := &bytes.Buffer{}
+		if err := jsonx.WriteTypeScript(buf, reflect.TypeOf(commented{})); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "  /** ends here *\\/ alert(1) /* and /** nests */\n") {
+			t.Fatal("unexpected result1", buf.String())
+		}
+		if !strings.Contains(buf.String(), `.describe("ends here */ alert(1) /* and /** nests")`) {
+			t.Fatal("unexpected result2", buf.String())
+		}
+	})
+
+	t.Run("[unsupported]", func(t *testing.T) {
+		type wrong struct {
+			Ch chan int `json:"ch"`
+		}
+		if err := jsonx.WriteTypeScript(&bytes.Buffer{}, reflect.TypeOf(wrong{})); err == nil {
+			t.Fatal("unexpected result1")
+		}
+		if err := jsonx.WriteTypeScript(&bytes.Buffer{}, reflect.TypeOf(0)); err == nil {
+			t.Fatal("unexpected result2")
+		}
+	})
+}
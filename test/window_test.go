@@ -0,0 +1,188 @@
+package test
+
+import (
+	"bytes"
+	stdErrors "errors"
+	"github.com/aivyss/jsonx"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/errors"
+	"github.com/aivyss/jsonx/jsonxtest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeWindows(t *testing.T) {
+	jsonx.Close()
+
+	now := time.Date(2024, time.February, 29, 12, 30, 0, 0, time.UTC)
+
+	t.Run("[time]", func(t *testing.T) {
+		jsonxtest.UseClock(t, now)
+
+		for annoStr, cases := range map[string]map[time.Time]bool{
+			"PastWithin(24h)": {
+				now:                       true,
+				now.Add(-24 * time.Hour):  true,
+				now.Add(-25 * time.Hour):  false,
+				now.Add(time.Millisecond): false,
+				now.Add(-time.Nanosecond): true,
+			},
+			"FutureAfter(15m)": {
+				now.Add(15 * time.Minute): true,
+				now.Add(time.Hour):        true,
+				now.Add(14 * time.Minute): false,
+				now.Add(-time.Hour):       false,
+			},
+			"After(2020-01-01)": {
+				time.Date(2020, time.January, 1, 0, 0, 0, 1, time.UTC):                       true,
+				time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC):                       false,
+				time.Date(2020, time.January, 1, 8, 0, 0, 0, time.FixedZone("KST", 9*60*60)): false,
+			},
+			"Before(2020-01-01T09:00:00+09:00)": {
+				time.Date(2019, time.December, 31, 23, 59, 59, 0, time.UTC): true,
+				time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC):      false,
+			},
+			"Between(1900-01-01, 2100-01-01)": {
+				time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC):   true,
+				time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC):   true,
+				time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC): false,
+				time.Date(2100, time.January, 1, 0, 0, 1, 0, time.UTC):   false,
+			},
+		} {
+			annotation, err := definitions.ConvertToAnnotation(annoStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for value, valid := range cases {
+				err := annotation.Validate(value)
+				if valid != (err == nil) {
+					t.Fatal("unexpected result1", annoStr, value, err)
+				}
+				if err := annotation.Validate(&value); valid != (err == nil) {
+					t.Fatal("unexpected result2", annoStr, value, err)
+				}
+			}
+
+			var annotationErr *errors.AnnotationError
+			if err := annotation.Validate((*time.Time)(nil)); !stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonNil {
+				t.Fatal("unexpected result3", annoStr, err)
+			}
+			if err := annotation.Validate("2024-02-29"); !stdErrors.As(err, &annotationErr) || annotationErr.Reason != errors.ReasonWrongType {
+				t.Fatal("unexpected result4", annoStr, err)
+			}
+		}
+	})
+
+	t.Run("[strings]", func(t *testing.T) {
+		clock := jsonxtest.UseClock(t, now)
+
+		type event struct {
+			Seen  string  `json:"seen" annotation:"@PastWithin(1h, RFC3339)"`
+			Start *string `json:"start" annotation:"@FutureAfter(15m, '2006-01-02 15:04')"`
+			Birth string  `json:"birth" annotation:"@Between(1900-01-01, 2024-01-01, DateOnly)"`
+		}
+		if err := jsonx.Check[event](); err != nil {
+			t.Fatal(err)
+		}
+
+		start := "2024-02-29 13:00"
+		valid := event{Seen: "2024-02-29T12:00:00Z", Start: &start, Birth: "1990-12-24"}
+		if err := jsonx.Validate(valid); err != nil {
+			t.Fatal("unexpected result1", err)
+		}
+
+		late := "2024-02-29 12:40"
+		for i, tc := range []struct {
+			event  event
+			field  string
+			reason errors.AnnotationReason
+		}{
+			{event: event{Seen: "2024-02-29T11:29:59Z", Start: &start, Birth: valid.Birth}, field: "seen", reason: errors.ReasonRange},
+			{event: event{Seen: "yesterday", Start: &start, Birth: valid.Birth}, field: "seen", reason: errors.ReasonFormat},
+			{event: event{Seen: valid.Seen, Start: &late, Birth: valid.Birth}, field: "start", reason: errors.ReasonRange},
+			{event: event{Seen: valid.Seen, Start: &start, Birth: "2024-01-02"}, field: "birth", reason: errors.ReasonRange},
+		} {
+			var annotationErr *errors.AnnotationError
+			var validationErr *errors.ValidationError
+			err := jsonx.Validate(tc.event)
+			if !stdErrors.As(err, &validationErr) || validationErr.Field != tc.field ||
+				!stdErrors.As(err, &annotationErr) || annotationErr.Reason != tc.reason {
+				t.Fatal("unexpected result2", i, err)
+			}
+		}
+
+		// the window moves with the clock
+		clock.Advance(time.Hour)
+		if err := jsonx.Validate(valid); err == nil {
+			t.Fatal("unexpected result3")
+		}
+
+		type wrong struct {
+			Seen string `json:"seen" annotation:"@PastWithin(1h)"`
+		}
+		if err := jsonx.Check[wrong](); err == nil || !strings.Contains(err.Error(), "@PastWithin does not support string") {
+			t.Fatal("unexpected result4", err)
+		}
+	})
+
+	t.Run("[messages]", func(t *testing.T) {
+		jsonxtest.UseClock(t, now)
+
+		type event struct {
+			At time.Time `json:"at" annotation:"@Between(2020-01-01, 2021-01-01)"`
+		}
+		err := jsonx.Validate(event{At: now})
+		if msg := jsonx.Message(err, ""); msg != "at must be between 2020-01-01 and 2021-01-01" {
+			t.Fatal("unexpected result1", msg)
+		}
+		if msg := jsonx.Message(err, "ko"); msg != "at은(는) 2020-01-01부터 2021-01-01까지여야 합니다" {
+			t.Fatal("unexpected result2", msg)
+		}
+	})
+
+	t.Run("[wrong arguments]", func(t *testing.T) {
+		for _, annoStr := range []string{
+			"PastWithin", "PastWithin(-1h)", "PastWithin(0s)", "PastWithin(1h,RFC3339,DateOnly)", "FutureAfter(soon)",
+			"After(2020-13-01)", "After(2020-01-01,banana)", "Before", "Between(2020-01-01)", "Between(2021-01-01,2020-01-01)",
+		} {
+			if _, err := definitions.ConvertToAnnotation(annoStr); err == nil {
+				t.Fatal("unexpected result", annoStr)
+			}
+		}
+	})
+}
+
+func TestTimeWindowSchemas(t *testing.T) {
+	jsonx.Close()
+
+	type tsWindow struct {
+		Seen  time.Time `json:"seen" annotation:"@PastWithin(24h)"`
+		Start time.Time `json:"start" annotation:"@FutureAfter(15m)"`
+		Birth string    `json:"birth" annotation:"@Between(1900-01-01, 2100-01-01, DateOnly)"`
+		After time.Time `json:"after" annotation:"@After(2020-01-01)"`
+	}
+
+	schema, err := jsonx.Schema[tsWindow]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSONEqual(t, schema["properties"].(map[string]any)["birth"], `{"type": "string", "format": "date"}`)
+
+	buf := &bytes.Buffer{}
+	if err := jsonx.WriteTypeScript(buf, reflect.TypeOf(tsWindow{})); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`seen: z.string().datetime({ offset: true }).refine((v) => new Date(v).getTime() <= Date.now() && new Date(v).getTime() >= Date.now() - 86400000`,
+		`start: z.string().datetime({ offset: true }).refine((v) => new Date(v).getTime() >= Date.now() + 900000`,
+		`birth: z.string().date().refine((v) => new Date(v).getTime() >= Date.parse("1900-01-01") && new Date(v).getTime() <= Date.parse("2100-01-01")`,
+		`after: z.string().datetime({ offset: true }).refine((v) => new Date(v).getTime() > Date.parse("2020-01-01")`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatal("unexpected result", expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,696 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aivyss/jsonx/definitions"
+	"github.com/aivyss/jsonx/tag"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var tsIdentifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// WriteTypeScript
+// writes a TypeScript interface and a Zod schema for every type and the structs they refer to.
+// without types, the types registered by MustRegisterType are written.
+// pointers are optional unless an annotation rejects nil, e.g. @Required or @NotBlank.
+func WriteTypeScript(w io.Writer, types ...reflect.Type) error {
+	if len(types) == 0 {
+		for t := range registeredTypes {
+			types = append(types, t)
+		}
+		sort.Slice(types, func(i, j int) bool {
+			return types[i].String() < types[j].String()
+		})
+	}
+
+	g := &tsGenerator{
+		names:     map[reflect.Type]string{},
+		taken:     map[string]bool{},
+		state:     map[reflect.Type]int{},
+		recursive: map[reflect.Type]bool{},
+	}
+	for _, t := range types {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			return fmt.Errorf("%s: use only named struct type", t)
+		}
+
+		if _, err := g.named(t); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "// Code generated by jsonx. DO NOT EDIT.\n\nimport { z } from \"zod\";\n"+g.out.String())
+	return err
+}
+
+const (
+	tsVisiting = iota + 1
+	tsDone
+)
+
+// tsGenerator
+// named structs are written once, after the structs they refer to
+type tsGenerator struct {
+	out       strings.Builder
+	names     map[reflect.Type]string
+	taken     map[string]bool
+	state     map[reflect.Type]int
+	recursive map[reflect.Type]bool
+}
+
+// tsType
+// a TypeScript type and the Zod schema of the same value
+type tsType struct {
+	ts  string
+	zod string
+}
+
+// named
+// reference to a named struct, writing it first if needed.
+// a reference back to a struct being written is lazy.
+func (g *tsGenerator) named(t reflect.Type) (tsType, error) {
+	switch g.state[t] {
+	case tsVisiting:
+		g.recursive[t] = true
+		return tsType{ts: g.names[t], zod: fmt.Sprintf("z.lazy(() => %sSchema)", g.names[t])}, nil
+	case tsDone:
+		return tsType{ts: g.names[t], zod: g.names[t] + "Schema"}, nil
+	}
+
+	name := g.tsName(t)
+	g.names[t] = name
+	g.state[t] = tsVisiting
+
+	fields, err := g.fields(t)
+	if err != nil {
+		return tsType{}, err
+	}
+	g.state[t] = tsDone
+
+	fmt.Fprintf(&g.out, "\nexport interface %s {\n", name)
+	for _, f := range fields {
+		if f.doc != "" {
+			fmt.Fprintf(&g.out, "  /** %s */\n", tsComment(f.doc))
+		}
+		fmt.Fprintf(&g.out, "  %s;\n", f.tsProperty())
+	}
+	g.out.WriteString("}\n\n")
+
+	if g.recursive[t] {
+		fmt.Fprintf(&g.out, "export const %sSchema: z.ZodType<%s> = z.object({\n", name, name)
+	} else {
+		fmt.Fprintf(&g.out, "export const %sSchema = z.object({\n", name)
+	}
+	for _, f := range fields {
+		fmt.Fprintf(&g.out, "  %s: %s,\n", tsKey(f.name), f.zod)
+	}
+	g.out.WriteString("});\n")
+
+	return tsType{ts: name, zod: name + "Schema"}, nil
+}
+
+// tsName
+// the Go type name starting with an upper case letter, suffixed when it is taken
+func (g *tsGenerator) tsName(t reflect.Type) string {
+	base := []rune(t.Name())
+	base[0] = unicode.ToUpper(base[0])
+
+	name := string(base)
+	for i := 2; g.taken[name]; i++ {
+		name = string(base) + strconv.Itoa(i)
+	}
+	g.taken[name] = true
+
+	return name
+}
+
+type tsField struct {
+	name     string
+	doc      string
+	optional bool
+	tsType
+}
+
+func (f tsField) tsProperty() string {
+	if f.optional {
+		return tsKey(f.name) + "?: " + f.ts
+	}
+
+	return tsKey(f.name) + ": " + f.ts
+}
+
+// fields
+// properties of a struct, embedded structs are flattened like encoding/json does
+func (g *tsGenerator) fields(typeOf reflect.Type) ([]tsField, error) {
+	fields := make([]tsField, 0, typeOf.NumField())
+
+	for _, plan := range planOf(typeOf) {
+		if !plan.isVisible() || plan.isJSONIgnored() {
+			continue
+		}
+
+		if plan.isPromoted() {
+			promoted, err := g.fields(plan.nested)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, promoted...)
+			continue
+		}
+
+		field, err := g.field(plan)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeOf, plan.field.Name, err)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func (g *tsGenerator) field(plan fieldPlan) (tsField, error) {
+	t := plan.field.Type
+	annotations := make([]*definitions.Annotation, 0)
+	for _, annoStr := range tag.SplitAnnotationTag(plan.annotations) {
+		annotation, err := definitions.ConvertToAnnotation(annoStr)
+		if err != nil {
+			return tsField{}, err
+		}
+		annotations = append(annotations, annotation)
+	}
+
+	typ, err := g.valueType(elemType(t), plan, annotations)
+	if err != nil {
+		return tsField{}, err
+	}
+
+	field := tsField{name: plan.jsonName(), doc: plan.doc, tsType: typ}
+	switch {
+	case rejectsNil(t, annotations):
+	case t.Kind() == reflect.Pointer:
+		field.optional = true
+		field.ts += " | null"
+		field.zod += ".nullish()"
+	case t.Kind() == reflect.Map || (t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8), t == dateType:
+		// the zero Date encodes as null
+		field.ts += " | null"
+		field.zod += ".nullable()"
+	}
+	if plan.doc != "" {
+		field.zod += fmt.Sprintf(".describe(%s)", tsString(plan.doc))
+	}
+
+	return field, nil
+}
+
+// valueType
+// the type of a non-nil value with the rules of the annotations and pattern applied
+func (g *tsGenerator) valueType(t reflect.Type, plan fieldPlan, annotations []*definitions.Annotation) (tsType, error) {
+	if plan.jsonOption("string") && isScalarKind(t.Kind()) {
+		return tsType{ts: "string", zod: "z.string()"}, nil
+	}
+
+	typ, err := g.typeOf(t)
+	if err != nil {
+		return tsType{}, err
+	}
+
+	// element rules first, they rebuild the array schema
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		typ = g.elementsType(typ, t, annotations)
+	}
+	for _, annotation := range annotations {
+		typ = g.applyAnnotation(typ, t, annotation)
+	}
+	if plan.pattern != "" {
+		typ.zod += fmt.Sprintf(".regex(%s)", tsRegex(plan.pattern))
+	}
+
+	return typ, nil
+}
+
+// applyAnnotation
+// maps built-in annotations to Zod methods, custom annotations are checked on the server only
+func (g *tsGenerator) applyAnnotation(typ tsType, t reflect.Type, annotation *definitions.Annotation) tsType {
+	params := annotation.Params()
+
+	if annotation.Name() == "Port" && isIntegerKind(t.Kind()) {
+		typ.zod += ".min(1).max(65535)"
+		return typ
+	}
+	if isFormatAnnotation(annotation.Name()) {
+		if t.Kind() == reflect.String {
+			typ.zod += zodFormat(annotation)
+		}
+		return typ
+	}
+
+	if isNumericAnnotation(annotation.Name()) && !isJSONNumber(t) {
+		return typ
+	}
+
+	switch annotation.Name() {
+	case "NotBlank":
+		typ.zod += ".trim().min(1)"
+	case "NotEmpty":
+		typ.zod += ".min(1)"
+	case "Positive":
+		typ.zod += ".positive()"
+	case "Negative":
+		typ.zod += ".negative()"
+	case "PositiveOrZero":
+		typ.zod += ".nonnegative()"
+	case "NegativeOrZero":
+		typ.zod += ".nonpositive()"
+	case "Min":
+		if n, ok := boundNumber(t, params["min"]); ok {
+			typ.zod += fmt.Sprintf(".min(%v)", n)
+		}
+	case "Max":
+		if n, ok := boundNumber(t, params["max"]); ok {
+			typ.zod += fmt.Sprintf(".max(%v)", n)
+		}
+	case "Digits", "Scale", "MultipleOf", "Currency":
+		if step, ok := decimalStep(annotation); ok {
+			typ.zod += fmt.Sprintf(".multipleOf(%v)", schemaNumber(step))
+		}
+		if annotation.Name() == "Digits" {
+			limit := "1e" + params["integer"]
+			typ.zod += fmt.Sprintf(".gt(-%s).lt(%s)", limit, limit)
+		}
+	case "Length":
+		if t.Kind() != reflect.Map {
+			typ.zod += fmt.Sprintf(".min(%s).max(%s)", params["min"], params["max"])
+		}
+	case "Enum":
+		return enumType(t, annotation.Args())
+
+	case "Future", "Past", "FutureOrPresent", "PastOrPresent", "Present",
+		"PastWithin", "FutureAfter", "After", "Before", "Between":
+		if t == dateType && annotation.Name() != "Between" {
+			// dates are compared by day of the server clock, checked on the server only
+			return typ
+		}
+		if t == timeOfDayType || t == durationType || t == textDurationType {
+			return typ
+		}
+		if t.Kind() == reflect.String {
+			if layoutKeywords(params["layout"]) == nil {
+				// Date can not parse custom layouts, checked on the server only
+				return typ
+			}
+			typ.zod += zodLayout(params["layout"])
+		}
+		return applyTemporal(typ, annotation.Name(), params)
+	}
+
+	return typ
+}
+
+// applyTemporal
+// compares the parsed date with the clock of the client or with the bounds.
+// granularities are checked on the server only.
+func applyTemporal(typ tsType, name string, params map[string]string) tsType {
+	if params["granularity"] != "" {
+		return typ
+	}
+
+	// without tolerance the present is less than a second away, with one at most the tolerance away
+	ms, within, beyond := int64(1000), "<", ">="
+	if tolerance, err := time.ParseDuration(params["tolerance"]); err == nil {
+		ms, within, beyond = tolerance.Milliseconds(), "<=", ">"
+	}
+	mirror := strings.NewReplacer("<", ">", ">", "<")
+
+	switch name {
+	case "Future":
+		typ.zod += dateRefine("new Date(v).getTime() "+beyond+" Date.now()"+tsOffset(ms), "must be in the future")
+	case "Past":
+		typ.zod += dateRefine("new Date(v).getTime() "+mirror.Replace(beyond)+" Date.now()"+tsOffset(-ms), "must be in the past")
+	case "FutureOrPresent":
+		typ.zod += dateRefine("new Date(v).getTime() "+mirror.Replace(within)+" Date.now()"+tsOffset(-ms), "must be in the present or future")
+	case "PastOrPresent":
+		typ.zod += dateRefine("new Date(v).getTime() "+within+" Date.now()"+tsOffset(ms), "must be in the past or present")
+	case "Present":
+		typ.zod += dateRefine(fmt.Sprintf("Math.abs(new Date(v).getTime() - Date.now()) %s %d", within, ms), "must be the present time")
+	case "PastWithin":
+		d, _ := time.ParseDuration(params["duration"])
+		typ.zod += dateRefine(fmt.Sprintf("new Date(v).getTime() <= Date.now() && new Date(v).getTime() >= Date.now() - %d", d.Milliseconds()),
+			"must be within the past "+params["duration"])
+	case "FutureAfter":
+		d, _ := time.ParseDuration(params["duration"])
+		typ.zod += dateRefine(fmt.Sprintf("new Date(v).getTime() >= Date.now() + %d", d.Milliseconds()),
+			"must be at least "+params["duration"]+" in the future")
+	case "After":
+		typ.zod += dateRefine("new Date(v).getTime() > Date.parse("+tsString(params["after"])+")", "must be after "+params["after"])
+	case "Before":
+		typ.zod += dateRefine("new Date(v).getTime() < Date.parse("+tsString(params["before"])+")", "must be before "+params["before"])
+	case "Between":
+		typ.zod += dateRefine("new Date(v).getTime() >= Date.parse("+tsString(params["from"])+") && new Date(v).getTime() <= Date.parse("+tsString(params["to"])+")",
+			"must be between "+params["from"]+" and "+params["to"])
+	}
+
+	return typ
+}
+
+// tsOffset
+// " + 1000" or " - 1000" milliseconds, "" for 0
+func tsOffset(ms int64) string {
+	switch {
+	case ms > 0:
+		return fmt.Sprintf(" + %d", ms)
+	case ms < 0:
+		return fmt.Sprintf(" - %d", -ms)
+	default:
+		return ""
+	}
+}
+
+// typeOf
+// the type of a Go value as encoding/json encodes it
+func (g *tsGenerator) typeOf(t reflect.Type) (tsType, error) {
+	if t.Kind() == reflect.Pointer {
+		elem, err := g.typeOf(t.Elem())
+		if err != nil {
+			return tsType{}, err
+		}
+
+		return tsType{ts: elem.ts + " | null", zod: elem.zod + ".nullable()"}, nil
+	}
+
+	switch {
+	case t == timeType:
+		return tsType{ts: "string", zod: "z.string().datetime({ offset: true })"}, nil
+	case t == durationType:
+		return tsType{ts: "number", zod: "z.number().int()"}, nil
+	case t == dateType:
+		return tsType{ts: "string", zod: "z.string().date()"}, nil
+	case t == timeOfDayType:
+		return tsType{ts: "string", zod: fmt.Sprintf("z.string().regex(%s)", tsRegex(timeOfDayRegex))}, nil
+	case t == textDurationType:
+		return tsType{ts: "string", zod: fmt.Sprintf("z.string().regex(%s)", tsRegex(durationRegex))}, nil
+	case t == jsonNumberType:
+		return tsType{ts: "number", zod: "z.number()"}, nil
+	case t == bigIntType:
+		return tsType{ts: "number", zod: "z.number().int()"}, nil
+	case t == bigFloatType, t == bigRatType:
+		return tsType{ts: "string", zod: "z.string()"}, nil
+	case t == rawMessageType, t == fileType:
+		return tsType{ts: "unknown", zod: "z.unknown()"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return tsType{ts: "string", zod: "z.string()"}, nil
+	case reflect.Bool:
+		return tsType{ts: "boolean", zod: "z.boolean()"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return tsType{ts: "number", zod: "z.number().int()"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return tsType{ts: "number", zod: "z.number().int().nonnegative()"}, nil
+	case reflect.Float32, reflect.Float64:
+		return tsType{ts: "number", zod: "z.number()"}, nil
+	case reflect.Interface:
+		return tsType{ts: "unknown", zod: "z.unknown()"}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// base64
+			return tsType{ts: "string", zod: "z.string()"}, nil
+		}
+
+		elem, err := g.elemTypeOf(t)
+		if err != nil {
+			return tsType{}, err
+		}
+		typ := tsType{ts: tsArray(elem.ts), zod: fmt.Sprintf("z.array(%s)", elem.zod)}
+		if t.Kind() == reflect.Array {
+			typ.zod += fmt.Sprintf(".length(%d)", t.Len())
+		}
+
+		return typ, nil
+	case reflect.Map:
+		elem, err := g.elemTypeOf(t)
+		if err != nil {
+			return tsType{}, err
+		}
+
+		return tsType{
+			ts:  fmt.Sprintf("Record<string, %s>", elem.ts),
+			zod: fmt.Sprintf("z.record(z.string(), %s)", elem.zod),
+		}, nil
+	case reflect.Struct:
+		if t.Name() != "" {
+			return g.named(t)
+		}
+
+		return g.inline(t)
+	default:
+		return tsType{}, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// elemTypeOf
+// the type of the elements of a slice, array or map, Date elements are nullable like Date fields
+func (g *tsGenerator) elemTypeOf(t reflect.Type) (tsType, error) {
+	elem, err := g.typeOf(t.Elem())
+	if err != nil || t.Elem() != dateType {
+		return elem, err
+	}
+
+	return tsType{ts: elem.ts + " | null", zod: elem.zod + ".nullable()"}, nil
+}
+
+// inline
+// anonymous structs are written in place
+func (g *tsGenerator) inline(t reflect.Type) (tsType, error) {
+	fields, err := g.fields(t)
+	if err != nil {
+		return tsType{}, err
+	}
+
+	tsProperties := make([]string, 0, len(fields))
+	zodProperties := make([]string, 0, len(fields))
+	for _, f := range fields {
+		tsProperties = append(tsProperties, f.tsProperty())
+		zodProperties = append(zodProperties, tsKey(f.name)+": "+f.zod)
+	}
+
+	return tsType{
+		ts:  "{ " + strings.Join(tsProperties, "; ") + " }",
+		zod: "z.object({ " + strings.Join(zodProperties, ", ") + " })",
+	}, nil
+}
+
+// rejectsNil
+// whether an annotation fails on the zero value of a pointer, slice or map field
+func rejectsNil(t reflect.Type, annotations []*definitions.Annotation) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+	default:
+		return false
+	}
+
+	zero := reflect.Zero(t).Interface()
+	for _, annotation := range annotations {
+		if annotation.Validate != nil && annotation.Validate(zero) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func enumType(t reflect.Type, values []string) tsType {
+	literals := make([]string, 0, len(values))
+	for _, value := range values {
+		if isScalarKind(t.Kind()) {
+			literals = append(literals, value)
+		} else {
+			literals = append(literals, tsString(value))
+		}
+	}
+
+	if !isScalarKind(t.Kind()) {
+		return tsType{
+			ts:  strings.Join(literals, " | "),
+			zod: fmt.Sprintf("z.enum([%s])", strings.Join(literals, ", ")),
+		}
+	}
+
+	zodLiterals := make([]string, 0, len(literals))
+	for _, literal := range literals {
+		zodLiterals = append(zodLiterals, fmt.Sprintf("z.literal(%s)", literal))
+	}
+	if len(zodLiterals) == 1 {
+		return tsType{ts: literals[0], zod: zodLiterals[0]}
+	}
+
+	return tsType{
+		ts:  strings.Join(literals, " | "),
+		zod: fmt.Sprintf("z.union([%s])", strings.Join(zodLiterals, ", ")),
+	}
+}
+
+// elementsType
+// element rules of @NotContainsNil, @NotContainsEmpty, @NotContainsBlank and format annotations
+func (g *tsGenerator) elementsType(typ tsType, t reflect.Type, annotations []*definitions.Annotation) tsType {
+	if t.Elem().Kind() == reflect.Uint8 {
+		return typ
+	}
+
+	elem, err := g.typeOf(elemType(t.Elem()))
+	if err != nil {
+		return typ
+	}
+
+	nullable := t.Elem().Kind() == reflect.Pointer || t.Elem() == dateType
+	changed := false
+	for _, annotation := range annotations {
+		switch name := annotation.Name(); {
+		case name == "NotContainsNil":
+			nullable = false
+		case name == "NotContainsEmpty":
+			elem.zod += ".min(1)"
+			nullable = false
+		case name == "NotContainsBlank":
+			elem.zod += ".trim().min(1)"
+			nullable = false
+		case isFormatAnnotation(name) && elemType(t.Elem()).Kind() == reflect.String:
+			elem.zod += zodFormat(annotation)
+		default:
+			continue
+		}
+		changed = true
+	}
+	if !changed {
+		return typ
+	}
+
+	if nullable {
+		elem.ts += " | null"
+		elem.zod += ".nullable()"
+	}
+	typ = tsType{ts: tsArray(elem.ts), zod: fmt.Sprintf("z.array(%s)", elem.zod)}
+	if t.Kind() == reflect.Array {
+		typ.zod += fmt.Sprintf(".length(%d)", t.Len())
+	}
+
+	return typ
+}
+
+// zodFormat
+// Zod methods of a format annotation
+func zodFormat(annotation *definitions.Annotation) string {
+	switch annotation.Name() {
+	case "Email":
+		if maxLength := emailMaxLength(annotation); maxLength > 0 {
+			return fmt.Sprintf(".email().max(%d)", maxLength)
+		}
+		return ".email()"
+	case "UUID":
+		return ".uuid()"
+	case "DateTime":
+		return zodLayout(annotation.Params()["layout"])
+	case "Date":
+		return ".date()"
+	case "RFC3339":
+		return ".datetime({ offset: true })"
+	case "Timezone":
+		return ""
+	case "ULID":
+		return ".ulid()"
+	case "URL":
+		if pattern := schemePattern(annotation); pattern != "" {
+			return fmt.Sprintf(".url().regex(%s)", tsRegex(pattern))
+		}
+		return ".url()"
+	case "URI":
+		return ".url()"
+	case "Hostname":
+		return fmt.Sprintf(".max(253).regex(%s)", tsRegex(formatPattern(annotation)))
+	case "IP":
+		return ".ip()"
+	case "IPv4":
+		return `.ip({ version: "v4" })`
+	case "IPv6":
+		return `.ip({ version: "v6" })`
+	default:
+		return fmt.Sprintf(".regex(%s)", tsRegex(formatPattern(annotation)))
+	}
+}
+
+// zodLayout
+// Zod methods of a layout argument, "" for layouts without Zod format
+func zodLayout(layout string) string {
+	switch layoutKeywords(layout)["format"] {
+	case "date-time":
+		return ".datetime({ offset: true })"
+	case "date":
+		return ".date()"
+	default:
+		return ""
+	}
+}
+
+func dateRefine(condition, msg string) string {
+	return fmt.Sprintf(".refine((v) => %s, { message: %s })", condition, tsString(msg))
+}
+
+func tsArray(elem string) string {
+	if strings.Contains(elem, "|") {
+		return "(" + elem + ")[]"
+	}
+
+	return elem + "[]"
+}
+
+func tsKey(name string) string {
+	if tsIdentifierRegex.MatchString(name) {
+		return name
+	}
+
+	return tsString(name)
+}
+
+func tsString(s string) string {
+	j, _ := json.Marshal(s)
+	return string(j)
+}
+
+// tsComment
+// the text of a doc comment, a "*/" in it would end the comment
+func tsComment(s string) string {
+	return strings.ReplaceAll(s, "*/", "*\\/")
+}
+
+// tsRegex
+// a JavaScript regular expression literal, a leading (?i) becomes the i flag
+func tsRegex(pattern string) string {
+	flags := ""
+	if rest, ok := strings.CutPrefix(pattern, "(?i)"); ok {
+		pattern, flags = rest, "i"
+	}
+
+	var b strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		if r == '/' && !escaped {
+			b.WriteRune('\\')
+		}
+		escaped = r == '\\' && !escaped
+		b.WriteRune(r)
+	}
+
+	return "/" + b.String() + "/" + flags
+}